@@ -22,13 +22,15 @@ type MD5Reader struct {
 	body io.Reader
 }
 
-// Read - 지정한 byte배열에 관리 중인 정보를 MD5로 출력하고 크기와 오류를 반환
+// Read - 원본 Reader에서 지정한 byte배열로 읽은 내용을 MD5에 반영하고 읽은 크기와 오류를 반환
+// conditions:
+// - 원본 Reader가 데이터와 io.EOF를 함께 반환하는 경우도 읽은 데이터를 MD5에 반영한다.
 func (r *MD5Reader) Read(b []byte) (int, error) {
 	n, err := r.body.Read(b)
-	if err != nil {
-		return n, err
+	if n > 0 {
+		r.md5.Write(b[:n]) // hash.Hash 의 Write 는 오류를 반환하지 않는다.
 	}
-	return r.md5.Write(b[:n])
+	return n, err
 }
 
 // MD5 - 관리 중인 데이터를 MD5 byte 배열로 반환
@@ -0,0 +1,99 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package hash
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"strings"
+)
+
+// ===== [ Constants and Variables ] =====
+const (
+	MD5    Algorithm = "md5"    // MD5 (128 bits)
+	SHA1   Algorithm = "sha1"   // SHA-1 (160 bits)
+	SHA256 Algorithm = "sha256" // SHA-256 (256 bits)
+	SHA384 Algorithm = "sha384" // SHA-384 (384 bits)
+	SHA512 Algorithm = "sha512" // SHA-512 (512 bits)
+	CRC32C Algorithm = "crc32c" // CRC-32 Castagnoli (32 bits)
+)
+
+var (
+	ErrUnsupportedAlgorithm = errors.New("unsupported hash algorithm") // 지원하지 않는 알고리즘인 경우
+)
+
+var crc32cTable = crc32.MakeTable(crc32.Castagnoli) // CRC32C 계산용 테이블
+
+// ========== [ Algorithm START ] =========
+
+// Algorithm - Hash 알고리즘 식별자
+type Algorithm string
+
+// New - 알고리즘에 해당하는 hash.Hash 생성
+func (a Algorithm) New() (hash.Hash, error) {
+	switch a {
+	case MD5:
+		return md5.New(), nil
+	case SHA1:
+		return sha1.New(), nil
+	case SHA256:
+		return sha256.New(), nil
+	case SHA384:
+		return sha512.New384(), nil
+	case SHA512:
+		return sha512.New(), nil
+	case CRC32C:
+		return crc32.New(crc32cTable), nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, string(a))
+}
+
+// Size - 알고리즘의 Digest 크기 (bytes) 반환
+// conditions:
+// - 지원하지 않는 알고리즘인 경우는 0 반환
+func (a Algorithm) Size() int {
+	h, err := a.New()
+	if err != nil {
+		return 0
+	}
+	return h.Size()
+}
+
+// Available - 지원하는 알고리즘인지 여부
+func (a Algorithm) Available() bool {
+	_, err := a.New()
+	return err == nil
+}
+
+// String - 알고리즘 이름 반환
+func (a Algorithm) String() string {
+	return string(a)
+}
+
+// ========== [ Algorithm END ] =========
+
+// ===== [ Private Functions ] =====
+// ===== [ Public Functions ] =====
+
+// Algorithms - 지원하는 알고리즘 목록 반환
+func Algorithms() []Algorithm {
+	return []Algorithm{MD5, SHA1, SHA256, SHA384, SHA512, CRC32C}
+}
+
+// ParseAlgorithm - 지정한 문자열을 알고리즘으로 변환
+// conditions:
+// - 대소문자를 구분하지 않으며, `SHA-256` 처럼 '-' 가 포함된 형식도 허용한다.
+func ParseAlgorithm(name string) (Algorithm, error) {
+	a := Algorithm(strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "-", ""))
+	if !a.Available() {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, name)
+	}
+	return a, nil
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package hash
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/fnv"
+	"math"
+	"math/bits"
+)
+
+// ===== [ Constants and Variables ] =====
+const (
+	bloomMagic   = "BLM1" // BloomFilter 직렬화 식별자
+	bloomHeader  = 4 + 8 + 4 + 8 + 8
+	maxBloomBits = 1 << 40 // 생성 가능한 최대 Bit 수 (128 GiB)
+)
+
+var (
+	ErrIncompatibleSketch = errors.New("incompatible sketch")  // 크기나 Seed 가 달라 병합할 수 없는 경우
+	ErrInvalidSketchData  = errors.New("invalid sketch data")  // 직렬화된 데이터 형식이 올바르지 않은 경우
+	ErrInvalidSketchParam = errors.New("invalid sketch param") // 생성 인자가 올바르지 않은 경우
+)
+
+// ========== [ BloomFilter START ] =========
+
+// BloomFilter - 지정한 항목이 추가되었는지 확률적으로 판단하기 위한 Bloom Filter 정보 관리용
+// conditions:
+// - 추가된 항목은 항상 존재한다고 판단하며, 추가되지 않은 항목은 설정한 확률 이하로 존재한다고 잘못 판단한다.
+// - Seed 를 기준으로 한 Double Hashing 으로 K 개의 위치를 계산한다.
+// - 동시 사용에 안전하지 않으므로 호출하는 쪽에서 동기화해야 한다.
+type BloomFilter struct {
+	m     uint64   // Bit 수
+	k     uint32   // Hash 함수 수
+	seed  uint64   // Hash Seed
+	count uint64   // 추가한 항목 수 (중복 포함)
+	bits  []uint64 // Bit 배열
+}
+
+// Add - 지정한 항목 추가
+func (f *BloomFilter) Add(key []byte) {
+	h1, h2 := doubleHash(f.seed, key)
+	for i := uint32(0); i < f.k; i++ {
+		idx := (h1 + uint64(i)*h2) % f.m
+		f.bits[idx>>6] |= 1 << (idx & 63)
+	}
+	f.count++
+}
+
+// AddString - 지정한 문자열 항목 추가
+func (f *BloomFilter) AddString(key string) {
+	f.Add([]byte(key))
+}
+
+// Test - 지정한 항목이 추가되었을 가능성이 있는지 여부
+// conditions:
+// - false 인 경우는 추가되지 않은 것이 확실하다.
+func (f *BloomFilter) Test(key []byte) bool {
+	h1, h2 := doubleHash(f.seed, key)
+	for i := uint32(0); i < f.k; i++ {
+		idx := (h1 + uint64(i)*h2) % f.m
+		if f.bits[idx>>6]&(1<<(idx&63)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// TestString - 지정한 문자열 항목이 추가되었을 가능성이 있는지 여부
+func (f *BloomFilter) TestString(key string) bool {
+	return f.Test([]byte(key))
+}
+
+// TestAndAdd - 지정한 항목이 추가되었을 가능성이 있는지 여부를 반환하고 항목 추가
+// conditions:
+// - 중복 제거 용도로 사용하며, true 인 경우는 이미 처리된 항목으로 판단한다.
+func (f *BloomFilter) TestAndAdd(key []byte) bool {
+	exists := f.Test(key)
+	f.Add(key)
+	return exists
+}
+
+// TestAndAddString - 지정한 문자열 항목으로 TestAndAdd 처리
+func (f *BloomFilter) TestAndAddString(key string) bool {
+	return f.TestAndAdd([]byte(key))
+}
+
+// Cap - Bit 수 반환
+func (f *BloomFilter) Cap() uint64 {
+	return f.m
+}
+
+// K - Hash 함수 수 반환
+func (f *BloomFilter) K() uint32 {
+	return f.k
+}
+
+// Count - 추가한 항목 수 반환 (중복 포함)
+func (f *BloomFilter) Count() uint64 {
+	return f.count
+}
+
+// FalsePositiveRate - 현재 설정된 Bit 비율로 추정한 오탐 확률 반환
+func (f *BloomFilter) FalsePositiveRate() float64 {
+	var set int
+	for _, w := range f.bits {
+		set += bits.OnesCount64(w)
+	}
+	return math.Pow(float64(set)/float64(f.m), float64(f.k))
+}
+
+// Merge - 지정한 BloomFilter 의 항목들을 병합
+// conditions:
+// - Bit 수, Hash 함수 수, Seed 가 같은 경우만 병합할 수 있다.
+func (f *BloomFilter) Merge(other *BloomFilter) error {
+	if f.m != other.m || f.k != other.k || f.seed != other.seed {
+		return ErrIncompatibleSketch
+	}
+	for i := range f.bits {
+		f.bits[i] |= other.bits[i]
+	}
+	f.count += other.count
+	return nil
+}
+
+// Reset - 모든 항목 제거
+func (f *BloomFilter) Reset() {
+	for i := range f.bits {
+		f.bits[i] = 0
+	}
+	f.count = 0
+}
+
+// MarshalBinary - 바이너리 형식으로 직렬화
+// conditions:
+// - `BLM1` + m + k + seed + count + bits 를 Big Endian 으로 기록한다.
+func (f *BloomFilter) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, bloomHeader+8*len(f.bits))
+	copy(buf, bloomMagic)
+	binary.BigEndian.PutUint64(buf[4:], f.m)
+	binary.BigEndian.PutUint32(buf[12:], f.k)
+	binary.BigEndian.PutUint64(buf[16:], f.seed)
+	binary.BigEndian.PutUint64(buf[24:], f.count)
+	for i, w := range f.bits {
+		binary.BigEndian.PutUint64(buf[bloomHeader+8*i:], w)
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary - MarshalBinary 로 직렬화된 데이터 복원
+func (f *BloomFilter) UnmarshalBinary(data []byte) error {
+	if len(data) < bloomHeader || string(data[:4]) != bloomMagic {
+		return ErrInvalidSketchData
+	}
+	m := binary.BigEndian.Uint64(data[4:])
+	k := binary.BigEndian.Uint32(data[12:])
+	if m == 0 || m > maxBloomBits || bloomWords(m) > maxSketchWords(bloomHeader) || k == 0 || uint64(len(data)-bloomHeader) != 8*bloomWords(m) {
+		return ErrInvalidSketchData
+	}
+
+	f.m, f.k = m, k
+	f.seed = binary.BigEndian.Uint64(data[16:])
+	f.count = binary.BigEndian.Uint64(data[24:])
+	f.bits = make([]uint64, bloomWords(m))
+	for i := range f.bits {
+		f.bits[i] = binary.BigEndian.Uint64(data[bloomHeader+8*i:])
+	}
+	return nil
+}
+
+// ========== [ BloomFilter END ] =========
+
+// ===== [ Private Functions ] =====
+
+// doubleHash - Seed 를 반영한 FNV-1a 값으로 Double Hashing 에 사용할 두 Hash 값 계산
+// conditions:
+// - 두번째 값은 항상 홀수로 만들어 위치들이 한 곳에 몰리지 않도록 한다.
+func doubleHash(seed uint64, key []byte) (uint64, uint64) {
+	var s [8]byte
+	binary.BigEndian.PutUint64(s[:], seed)
+
+	h := fnv.New64a()
+	h.Write(s[:])
+	h.Write(key)
+	sum := h.Sum64()
+
+	return Mix64(sum), Mix64(sum^0x9e3779b97f4a7c15) | 1
+}
+
+// maxSketchWords - 직렬화 Header 를 포함해서 현재 플랫폼의 int 범위에 할당할 수 있는 최대 uint64 수
+func maxSketchWords(header int) uint64 {
+	return uint64(math.MaxInt-header) / 8
+}
+
+// bloomWords - 지정한 Bit 수를 저장할 uint64 수
+func bloomWords(m uint64) uint64 {
+	return (m + 63) / 64
+}
+
+// ===== [ Public Functions ] =====
+
+// NewBloomFilter - 예상 항목 수와 오탐 확률로 Bit 수와 Hash 함수 수를 계산해서 BloomFilter 생성
+// conditions:
+// - m = -n·ln(p) / ln(2)², k = (m/n)·ln(2)
+// - 예상 항목 수는 1 이상, 오탐 확률은 0 과 1 사이여야 한다.
+func NewBloomFilter(expectedItems uint64, falsePositiveRate float64, seed uint64) (*BloomFilter, error) {
+	if expectedItems == 0 || falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		return nil, ErrInvalidSketchParam
+	}
+
+	n := float64(expectedItems)
+	m := math.Ceil(-n * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	if m > maxBloomBits {
+		return nil, ErrInvalidSketchParam
+	}
+	k := math.Max(1, math.Round(m/n*math.Ln2))
+
+	return NewBloomFilterWithSize(uint64(m), uint32(k), seed)
+}
+
+// NewBloomFilterWithSize - 지정한 Bit 수와 Hash 함수 수로 BloomFilter 생성
+// conditions:
+// - Bit 수가 maxBloomBits 또는 현재 플랫폼에서 할당할 수 있는 크기 (32 bit 환경은 약 16 Gbit) 를 넘으면 ErrInvalidSketchParam
+func NewBloomFilterWithSize(m uint64, k uint32, seed uint64) (*BloomFilter, error) {
+	if m == 0 || m > maxBloomBits || bloomWords(m) > maxSketchWords(bloomHeader) || k == 0 {
+		return nil, ErrInvalidSketchParam
+	}
+	return &BloomFilter{
+		m:    m,
+		k:    k,
+		seed: seed,
+		bits: make([]uint64, bloomWords(m)),
+	}, nil
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package hash
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// ===== [ Private Functions ] =====
+
+// mustBloomFilter - 테스트용 BloomFilter 생성
+func mustBloomFilter(t *testing.T, m uint64, k uint32, seed uint64) *BloomFilter {
+	f, err := NewBloomFilterWithSize(m, k, seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+// ===== [ Public Functions ] =====
+
+func TestBloomFilterFalsePositiveRate(t *testing.T) {
+	const (
+		items  = 10000
+		target = 0.01
+		probes = 100000
+	)
+	f, err := NewBloomFilter(items, target, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < items; i++ {
+		f.AddString("in-" + strconv.Itoa(i))
+	}
+
+	for i := 0; i < items; i++ {
+		if !f.TestString("in-" + strconv.Itoa(i)) {
+			t.Fatalf("added item in-%d reported as absent", i)
+		}
+	}
+
+	falsePositives := 0
+	for i := 0; i < probes; i++ {
+		if f.TestString("out-" + strconv.Itoa(i)) {
+			falsePositives++
+		}
+	}
+	if rate := float64(falsePositives) / probes; rate > target*1.5 {
+		t.Errorf("false positive rate %.4f exceeds target %.4f", rate, target)
+	}
+	if est := f.FalsePositiveRate(); est > target*1.5 {
+		t.Errorf("estimated false positive rate %.4f exceeds target %.4f", est, target)
+	}
+}
+
+func TestBloomFilterTestAndAdd(t *testing.T) {
+	f, err := NewBloomFilter(100, 0.001, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.TestAndAddString("a") {
+		t.Fatal("first TestAndAdd reported existing item")
+	}
+	if !f.TestAndAddString("a") {
+		t.Fatal("second TestAndAdd reported missing item")
+	}
+	if f.Count() != 2 {
+		t.Fatalf("Count = %d, expected 2", f.Count())
+	}
+
+	f.Reset()
+	if f.TestString("a") || f.Count() != 0 {
+		t.Fatal("Reset did not clear the filter")
+	}
+}
+
+func TestBloomFilterMerge(t *testing.T) {
+	a, _ := NewBloomFilterWithSize(4096, 4, 7)
+	b, _ := NewBloomFilterWithSize(4096, 4, 7)
+	a.AddString("a")
+	b.AddString("b")
+
+	if err := a.Merge(b); err != nil {
+		t.Fatal(err)
+	}
+	if !a.TestString("a") || !a.TestString("b") || a.Count() != 2 {
+		t.Fatal("merged filter lost items")
+	}
+
+	for _, other := range []*BloomFilter{
+		mustBloomFilter(t, 4096, 4, 8),
+		mustBloomFilter(t, 4096, 5, 7),
+		mustBloomFilter(t, 8192, 4, 7),
+	} {
+		if err := a.Merge(other); !errors.Is(err, ErrIncompatibleSketch) {
+			t.Errorf("Merge(m=%d, k=%d) returned %v, expected ErrIncompatibleSketch", other.Cap(), other.K(), err)
+		}
+	}
+}
+
+func TestBloomFilterBinary(t *testing.T) {
+	f, _ := NewBloomFilter(1000, 0.01, 99)
+	for i := 0; i < 500; i++ {
+		f.AddString(strconv.Itoa(i))
+	}
+
+	data, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var restored BloomFilter
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(f, &restored) {
+		t.Fatal("unmarshaled filter differs from original")
+	}
+
+	for name, bad := range map[string][]byte{
+		"empty":     nil,
+		"magic":     append([]byte("XXXX"), data[4:]...),
+		"truncated": data[:len(data)-1],
+		"extended":  append(append([]byte{}, data...), 0),
+	} {
+		if err := restored.UnmarshalBinary(bad); !errors.Is(err, ErrInvalidSketchData) {
+			t.Errorf("%s: UnmarshalBinary returned %v, expected ErrInvalidSketchData", name, err)
+		}
+	}
+}
+
+func TestNewBloomFilterInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		items uint64
+		rate  float64
+	}{
+		{0, 0.01},
+		{100, 0},
+		{100, 1},
+		{1 << 62, 0.0001},
+	} {
+		if _, err := NewBloomFilter(tc.items, tc.rate, 0); !errors.Is(err, ErrInvalidSketchParam) {
+			t.Errorf("NewBloomFilter(%d, %v) returned %v, expected ErrInvalidSketchParam", tc.items, tc.rate, err)
+		}
+	}
+	if _, err := NewBloomFilterWithSize(maxBloomBits+1, 1, 0); !errors.Is(err, ErrInvalidSketchParam) {
+		t.Errorf("NewBloomFilterWithSize(maxBloomBits+1) returned %v, expected ErrInvalidSketchParam", err)
+	}
+}
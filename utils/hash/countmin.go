@@ -0,0 +1,190 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package hash
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// ===== [ Constants and Variables ] =====
+const (
+	countMinMagic   = "CMS1" // CountMinSketch 직렬화 식별자
+	countMinHeader  = 4 + 4 + 4 + 8 + 8
+	maxCountMinCell = 1 << 32 // 생성 가능한 최대 카운터 수
+)
+
+var ()
+
+// ========== [ CountMinSketch START ] =========
+
+// CountMinSketch - 항목별 빈도를 고정된 메모리로 추정하기 위한 Count-Min Sketch 정보 관리용
+// conditions:
+// - 추정 빈도는 실제 빈도보다 작지 않으며, 확률 1-delta 로 실제 빈도 + epsilon·Total 이하이다.
+// - Seed 를 기준으로 한 Double Hashing 으로 행별 위치를 계산한다.
+// - 동시 사용에 안전하지 않으므로 호출하는 쪽에서 동기화해야 한다.
+type CountMinSketch struct {
+	width  uint32   // 행별 카운터 수
+	depth  uint32   // 행 수
+	seed   uint64   // Hash Seed
+	total  uint64   // 추가한 빈도 합계
+	counts []uint64 // depth x width 카운터
+}
+
+// Add - 지정한 항목의 빈도를 지정한 수만큼 증가
+// conditions:
+// - 카운터는 최대값에서 포화된다.
+func (s *CountMinSketch) Add(key []byte, count uint64) {
+	h1, h2 := doubleHash(s.seed, key)
+	for i := uint32(0); i < s.depth; i++ {
+		idx := uint64(i)*uint64(s.width) + (h1+uint64(i)*h2)%uint64(s.width)
+		s.counts[idx] = saturatingAdd(s.counts[idx], count)
+	}
+	s.total = saturatingAdd(s.total, count)
+}
+
+// AddString - 지정한 문자열 항목의 빈도를 지정한 수만큼 증가
+func (s *CountMinSketch) AddString(key string, count uint64) {
+	s.Add([]byte(key), count)
+}
+
+// Count - 지정한 항목의 추정 빈도 반환
+func (s *CountMinSketch) Count(key []byte) uint64 {
+	h1, h2 := doubleHash(s.seed, key)
+	min := uint64(math.MaxUint64)
+	for i := uint32(0); i < s.depth; i++ {
+		idx := uint64(i)*uint64(s.width) + (h1+uint64(i)*h2)%uint64(s.width)
+		if s.counts[idx] < min {
+			min = s.counts[idx]
+		}
+	}
+	return min
+}
+
+// CountString - 지정한 문자열 항목의 추정 빈도 반환
+func (s *CountMinSketch) CountString(key string) uint64 {
+	return s.Count([]byte(key))
+}
+
+// Total - 추가한 빈도 합계 반환
+func (s *CountMinSketch) Total() uint64 {
+	return s.total
+}
+
+// Width - 행별 카운터 수 반환
+func (s *CountMinSketch) Width() uint32 {
+	return s.width
+}
+
+// Depth - 행 수 반환
+func (s *CountMinSketch) Depth() uint32 {
+	return s.depth
+}
+
+// Merge - 지정한 CountMinSketch 의 빈도들을 병합
+// conditions:
+// - 크기와 Seed 가 같은 경우만 병합할 수 있다.
+func (s *CountMinSketch) Merge(other *CountMinSketch) error {
+	if s.width != other.width || s.depth != other.depth || s.seed != other.seed {
+		return ErrIncompatibleSketch
+	}
+	for i := range s.counts {
+		s.counts[i] = saturatingAdd(s.counts[i], other.counts[i])
+	}
+	s.total = saturatingAdd(s.total, other.total)
+	return nil
+}
+
+// Reset - 모든 빈도 제거
+func (s *CountMinSketch) Reset() {
+	for i := range s.counts {
+		s.counts[i] = 0
+	}
+	s.total = 0
+}
+
+// MarshalBinary - 바이너리 형식으로 직렬화
+// conditions:
+// - `CMS1` + width + depth + seed + total + counts 를 Big Endian 으로 기록한다.
+func (s *CountMinSketch) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, countMinHeader+8*len(s.counts))
+	copy(buf, countMinMagic)
+	binary.BigEndian.PutUint32(buf[4:], s.width)
+	binary.BigEndian.PutUint32(buf[8:], s.depth)
+	binary.BigEndian.PutUint64(buf[12:], s.seed)
+	binary.BigEndian.PutUint64(buf[20:], s.total)
+	for i, c := range s.counts {
+		binary.BigEndian.PutUint64(buf[countMinHeader+8*i:], c)
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary - MarshalBinary 로 직렬화된 데이터 복원
+func (s *CountMinSketch) UnmarshalBinary(data []byte) error {
+	if len(data) < countMinHeader || string(data[:4]) != countMinMagic {
+		return ErrInvalidSketchData
+	}
+	width := binary.BigEndian.Uint32(data[4:])
+	depth := binary.BigEndian.Uint32(data[8:])
+	cells := uint64(width) * uint64(depth)
+	if cells == 0 || cells > maxCountMinCell || cells > maxSketchWords(countMinHeader) || uint64(len(data)-countMinHeader) != 8*cells {
+		return ErrInvalidSketchData
+	}
+
+	s.width, s.depth = width, depth
+	s.seed = binary.BigEndian.Uint64(data[12:])
+	s.total = binary.BigEndian.Uint64(data[20:])
+	s.counts = make([]uint64, cells)
+	for i := range s.counts {
+		s.counts[i] = binary.BigEndian.Uint64(data[countMinHeader+8*i:])
+	}
+	return nil
+}
+
+// ========== [ CountMinSketch END ] =========
+
+// ===== [ Private Functions ] =====
+
+// saturatingAdd - 최대값을 넘지 않도록 더하기
+func saturatingAdd(a, b uint64) uint64 {
+	if a > math.MaxUint64-b {
+		return math.MaxUint64
+	}
+	return a + b
+}
+
+// ===== [ Public Functions ] =====
+
+// NewCountMinSketch - 허용 오차와 실패 확률로 크기를 계산해서 CountMinSketch 생성
+// conditions:
+// - width = ⌈e/epsilon⌉, depth = ⌈ln(1/delta)⌉
+// - epsilon 과 delta 는 0 과 1 사이여야 한다.
+func NewCountMinSketch(epsilon, delta float64, seed uint64) (*CountMinSketch, error) {
+	if epsilon <= 0 || epsilon >= 1 || delta <= 0 || delta >= 1 {
+		return nil, ErrInvalidSketchParam
+	}
+
+	width := math.Ceil(math.E / epsilon)
+	depth := math.Max(1, math.Ceil(math.Log(1/delta)))
+	if width*depth > maxCountMinCell {
+		return nil, ErrInvalidSketchParam
+	}
+	return NewCountMinSketchWithSize(uint32(width), uint32(depth), seed)
+}
+
+// NewCountMinSketchWithSize - 지정한 행별 카운터 수와 행 수로 CountMinSketch 생성
+// conditions:
+// - 카운터 수가 maxCountMinCell 또는 현재 플랫폼에서 할당할 수 있는 크기를 넘으면 ErrInvalidSketchParam
+func NewCountMinSketchWithSize(width, depth uint32, seed uint64) (*CountMinSketch, error) {
+	cells := uint64(width) * uint64(depth)
+	if cells == 0 || cells > maxCountMinCell || cells > maxSketchWords(countMinHeader) {
+		return nil, ErrInvalidSketchParam
+	}
+	return &CountMinSketch{
+		width:  width,
+		depth:  depth,
+		seed:   seed,
+		counts: make([]uint64, cells),
+	}, nil
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package hash
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// ===== [ Private Functions ] =====
+
+// zipfCounts - 검증용 빈도 (i 번째 항목은 1000/(i+1) 번)
+func zipfCounts(items int) map[string]uint64 {
+	counts := make(map[string]uint64, items)
+	for i := 0; i < items; i++ {
+		counts["key-"+strconv.Itoa(i)] = uint64(1000/(i+1)) + 1
+	}
+	return counts
+}
+
+// mustCountMinSketch - 테스트용 CountMinSketch 생성
+func mustCountMinSketch(t *testing.T, width, depth uint32, seed uint64) *CountMinSketch {
+	s, err := NewCountMinSketchWithSize(width, depth, seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// ===== [ Public Functions ] =====
+
+func TestCountMinSketchBounds(t *testing.T) {
+	const (
+		epsilon = 0.001
+		delta   = 0.01
+	)
+	s, err := NewCountMinSketch(epsilon, delta, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := zipfCounts(20000)
+	var total uint64
+	for key, n := range counts {
+		s.AddString(key, n)
+		total += n
+	}
+	if s.Total() != total {
+		t.Fatalf("Total = %d, expected %d", s.Total(), total)
+	}
+
+	bound := uint64(math.Ceil(epsilon * float64(total)))
+	exceeded := 0
+	for key, n := range counts {
+		est := s.CountString(key)
+		if est < n {
+			t.Fatalf("estimate %d for %s is below actual %d", est, key, n)
+		}
+		if est > n+bound {
+			exceeded++
+		}
+	}
+	if ratio := float64(exceeded) / float64(len(counts)); ratio > delta {
+		t.Errorf("%.4f of estimates exceed actual + epsilon·total, expected at most %.4f", ratio, delta)
+	}
+}
+
+func TestCountMinSketchMerge(t *testing.T) {
+	a, _ := NewCountMinSketchWithSize(512, 4, 5)
+	b, _ := NewCountMinSketchWithSize(512, 4, 5)
+	all, _ := NewCountMinSketchWithSize(512, 4, 5)
+	for i := 0; i < 1000; i++ {
+		key := strconv.Itoa(i % 37)
+		if i%2 == 0 {
+			a.AddString(key, 1)
+		} else {
+			b.AddString(key, 1)
+		}
+		all.AddString(key, 1)
+	}
+
+	if err := a.Merge(b); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(a, all) {
+		t.Fatal("merged sketch differs from sketch of the combined stream")
+	}
+
+	for _, other := range []*CountMinSketch{
+		mustCountMinSketch(t, 512, 4, 6),
+		mustCountMinSketch(t, 256, 4, 5),
+		mustCountMinSketch(t, 512, 3, 5),
+	} {
+		if err := a.Merge(other); !errors.Is(err, ErrIncompatibleSketch) {
+			t.Errorf("Merge(width=%d, depth=%d) returned %v, expected ErrIncompatibleSketch", other.Width(), other.Depth(), err)
+		}
+	}
+}
+
+func TestCountMinSketchSaturation(t *testing.T) {
+	s := mustCountMinSketch(t, 16, 2, 0)
+	s.AddString("a", math.MaxUint64)
+	s.AddString("a", 1)
+	if s.CountString("a") != math.MaxUint64 || s.Total() != math.MaxUint64 {
+		t.Fatalf("counter did not saturate: count=%d, total=%d", s.CountString("a"), s.Total())
+	}
+
+	s.Reset()
+	if s.CountString("a") != 0 || s.Total() != 0 {
+		t.Fatal("Reset did not clear the sketch")
+	}
+}
+
+func TestCountMinSketchBinary(t *testing.T) {
+	s, _ := NewCountMinSketch(0.01, 0.01, 11)
+	for i := 0; i < 500; i++ {
+		s.AddString(strconv.Itoa(i%50), uint64(i))
+	}
+
+	data, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var restored CountMinSketch
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(s, &restored) {
+		t.Fatal("unmarshaled sketch differs from original")
+	}
+
+	for name, bad := range map[string][]byte{
+		"empty":     nil,
+		"magic":     append([]byte("XXXX"), data[4:]...),
+		"truncated": data[:len(data)-1],
+		"extended":  append(append([]byte{}, data...), 0),
+	} {
+		if err := restored.UnmarshalBinary(bad); !errors.Is(err, ErrInvalidSketchData) {
+			t.Errorf("%s: UnmarshalBinary returned %v, expected ErrInvalidSketchData", name, err)
+		}
+	}
+}
+
+func TestNewCountMinSketchInvalid(t *testing.T) {
+	for _, tc := range []struct{ epsilon, delta float64 }{
+		{0, 0.1},
+		{1, 0.1},
+		{0.1, 0},
+		{0.1, 1},
+		{1e-12, 0.01},
+	} {
+		if _, err := NewCountMinSketch(tc.epsilon, tc.delta, 0); !errors.Is(err, ErrInvalidSketchParam) {
+			t.Errorf("NewCountMinSketch(%v, %v) returned %v, expected ErrInvalidSketchParam", tc.epsilon, tc.delta, err)
+		}
+	}
+	if _, err := NewCountMinSketchWithSize(0, 4, 0); !errors.Is(err, ErrInvalidSketchParam) {
+		t.Errorf("NewCountMinSketchWithSize(0, 4) returned %v, expected ErrInvalidSketchParam", err)
+	}
+}
@@ -0,0 +1,273 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package hash
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ===== [ Constants and Variables ] =====
+const ()
+
+var (
+	ErrInvalidDigest = errors.New("invalid digest") // Digest 형식이 올바르지 않은 경우
+)
+
+// multihashCodes - multiformats 의 multihash 알고리즘 코드
+var multihashCodes = map[Algorithm]uint64{
+	SHA1:   0x11,
+	SHA256: 0x12,
+	SHA512: 0x13,
+	SHA384: 0x20,
+	MD5:    0xd5,
+}
+
+// sriAlgorithms - Subresource Integrity 에서 허용하는 알고리즘
+var sriAlgorithms = map[Algorithm]bool{
+	SHA256: true,
+	SHA384: true,
+	SHA512: true,
+}
+
+// ========== [ Digest START ] =========
+
+// Digest - 알고리즘과 Digest 값 정보 관리용
+// conditions:
+// - OCI/Docker (`sha256:<hex>`), SRI (`sha384-<base64>`), multihash 형식 간의 변환을 지원한다.
+type Digest struct {
+	Algorithm Algorithm // Hash 알고리즘
+	Sum       []byte    // Digest 값
+}
+
+// Validate - 지원하는 알고리즘이고 Digest 길이가 알고리즘과 일치하는지 검증
+func (d Digest) Validate() error {
+	if !d.Algorithm.Available() {
+		return fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, d.Algorithm)
+	}
+	if len(d.Sum) != d.Algorithm.Size() {
+		return fmt.Errorf("%w: %s digest must be %d bytes, got %d", ErrInvalidDigest, d.Algorithm, d.Algorithm.Size(), len(d.Sum))
+	}
+	return nil
+}
+
+// Hex - Digest 값을 Hex 문자열로 반환
+func (d Digest) Hex() string {
+	return hex.EncodeToString(d.Sum)
+}
+
+// Base64 - Digest 값을 표준 Base64 문자열로 반환
+func (d Digest) Base64() string {
+	return base64.StdEncoding.EncodeToString(d.Sum)
+}
+
+// OCI - OCI/Docker 형식 (`<algorithm>:<hex>`) 문자열 반환
+func (d Digest) OCI() string {
+	return string(d.Algorithm) + ":" + d.Hex()
+}
+
+// SRI - Subresource Integrity 형식 (`<algorithm>-<base64>`) 문자열 반환
+// conditions:
+// - SRI 는 sha256, sha384, sha512 만 허용한다.
+func (d Digest) SRI() (string, error) {
+	if !sriAlgorithms[d.Algorithm] {
+		return "", fmt.Errorf("%w: %s is not allowed for SRI", ErrUnsupportedAlgorithm, d.Algorithm)
+	}
+	return string(d.Algorithm) + "-" + d.Base64(), nil
+}
+
+// Multihash - multihash 형식 (`<varint code><varint length><digest>`) 으로 반환
+func (d Digest) Multihash() ([]byte, error) {
+	code, ok := multihashCodes[d.Algorithm]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s has no multihash code", ErrUnsupportedAlgorithm, d.Algorithm)
+	}
+
+	buf := make([]byte, 2*binary.MaxVarintLen64+len(d.Sum))
+	n := binary.PutUvarint(buf, code)
+	n += binary.PutUvarint(buf[n:], uint64(len(d.Sum)))
+	n += copy(buf[n:], d.Sum)
+	return buf[:n], nil
+}
+
+// String - OCI/Docker 형식 문자열 반환
+func (d Digest) String() string {
+	return d.OCI()
+}
+
+// IsZero - 비어있는 Digest 인지 여부
+func (d Digest) IsZero() bool {
+	return d.Algorithm == "" && len(d.Sum) == 0
+}
+
+// Equal - 알고리즘과 Digest 값이 같은지 비교 (상수 시간 비교)
+func (d Digest) Equal(other Digest) bool {
+	return d.Algorithm == other.Algorithm && subtle.ConstantTimeCompare(d.Sum, other.Sum) == 1
+}
+
+// Verifier - 지정한 Reader 의 내용이 Digest 와 일치하는지 검증하는 Reader 생성
+func (d Digest) Verifier(reader io.Reader) (*VerifyingReader, error) {
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+
+	h, err := d.Algorithm.New()
+	if err != nil {
+		return nil, err
+	}
+	return NewVerifyingReader(reader, h, d.Sum), nil
+}
+
+// MarshalText - OCI/Docker 형식 문자열로 변환
+func (d Digest) MarshalText() ([]byte, error) {
+	if d.IsZero() {
+		return []byte{}, nil
+	}
+	return []byte(d.OCI()), nil
+}
+
+// UnmarshalText - ParseDigest 로 지원하는 형식의 문자열을 Digest 로 변환
+func (d *Digest) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*d = Digest{}
+		return nil
+	}
+
+	parsed, err := ParseDigest(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// ========== [ Digest END ] =========
+
+// ===== [ Private Functions ] =====
+
+// newDigest - 지정한 정보로 Digest 를 생성하고 검증
+func newDigest(algorithm Algorithm, sum []byte, s string) (Digest, error) {
+	d := Digest{Algorithm: algorithm, Sum: sum}
+	if err := d.Validate(); err != nil {
+		return Digest{}, fmt.Errorf("%w: %s", err, s)
+	}
+	return d, nil
+}
+
+// ===== [ Public Functions ] =====
+
+// NewDigest - 지정한 알고리즘과 Digest 값으로 Digest 생성
+func NewDigest(algorithm Algorithm, sum []byte) (Digest, error) {
+	d := Digest{Algorithm: algorithm, Sum: sum}
+	if err := d.Validate(); err != nil {
+		return Digest{}, err
+	}
+	return d, nil
+}
+
+// ParseOCIDigest - OCI/Docker 형식 (`sha256:<hex>`) 문자열을 Digest 로 변환
+// conditions:
+// - OCI 규격과 동일하게 Hex 는 소문자만 허용한다.
+func ParseOCIDigest(s string) (Digest, error) {
+	idx := strings.IndexByte(s, ':')
+	if idx <= 0 {
+		return Digest{}, fmt.Errorf("%w: %s", ErrInvalidDigest, s)
+	}
+
+	encoded := s[idx+1:]
+	if strings.ToLower(encoded) != encoded {
+		return Digest{}, fmt.Errorf("%w: %s", ErrInvalidDigest, s)
+	}
+	sum, err := hex.DecodeString(encoded)
+	if err != nil {
+		return Digest{}, fmt.Errorf("%w: %s", ErrInvalidDigest, s)
+	}
+	return newDigest(Algorithm(s[:idx]), sum, s)
+}
+
+// ParseSRI - Subresource Integrity 형식 (`sha384-<base64>`) 문자열을 Digest 로 변환
+// conditions:
+// - 하나의 Hash 표현만 처리하며, `?` 이후의 옵션은 무시한다.
+func ParseSRI(s string) (Digest, error) {
+	token := strings.TrimSpace(s)
+	if idx := strings.IndexByte(token, '?'); idx >= 0 {
+		token = token[:idx]
+	}
+
+	idx := strings.IndexByte(token, '-')
+	if idx <= 0 || !sriAlgorithms[Algorithm(token[:idx])] {
+		return Digest{}, fmt.Errorf("%w: %s", ErrInvalidDigest, s)
+	}
+	sum, err := base64.StdEncoding.DecodeString(token[idx+1:])
+	if err != nil {
+		return Digest{}, fmt.Errorf("%w: %s", ErrInvalidDigest, s)
+	}
+	return newDigest(Algorithm(token[:idx]), sum, s)
+}
+
+// ParseMultihash - multihash 형식의 byte 배열을 Digest 로 변환
+func ParseMultihash(b []byte) (Digest, error) {
+	code, n := binary.Uvarint(b)
+	if n <= 0 {
+		return Digest{}, ErrInvalidDigest
+	}
+	length, m := binary.Uvarint(b[n:])
+	if m <= 0 || uint64(len(b)-n-m) != length {
+		return Digest{}, ErrInvalidDigest
+	}
+
+	for algorithm, c := range multihashCodes {
+		if c == code {
+			return newDigest(algorithm, append([]byte(nil), b[n+m:]...), hex.EncodeToString(b))
+		}
+	}
+	return Digest{}, fmt.Errorf("%w: multihash code 0x%x", ErrUnsupportedAlgorithm, code)
+}
+
+// ParseDigest - OCI/Docker, SRI, 알고리즘 없는 Hex 형식의 문자열을 Digest 로 변환
+// conditions:
+// - 알고리즘 없는 Hex 는 길이로 알고리즘을 추정한다. (32: md5, 40: sha1, 64: sha256, 96: sha384, 128: sha512)
+func ParseDigest(s string) (Digest, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case strings.Contains(s, ":"):
+		return ParseOCIDigest(s)
+	case strings.Contains(s, "-"):
+		return ParseSRI(s)
+	}
+
+	sum, err := hex.DecodeString(s)
+	if err != nil {
+		return Digest{}, fmt.Errorf("%w: %s", ErrInvalidDigest, s)
+	}
+	algorithm := algorithmBySize(len(sum))
+	if algorithm == "" {
+		return Digest{}, fmt.Errorf("%w: %s", ErrInvalidDigest, s)
+	}
+	return Digest{Algorithm: algorithm, Sum: sum}, nil
+}
+
+// HexToBase64 - Hex 문자열을 표준 Base64 문자열로 변환
+func HexToBase64(s string) (string, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// Base64ToHex - 표준 Base64 문자열을 Hex 문자열로 변환
+func Base64ToHex(s string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package hash
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/bytefmt"
+)
+
+// ===== [ Constants and Variables ] =====
+const (
+	MinPartSize  = 5 * bytefmt.MEGABYTE // S3 Multipart Upload 최소 Part 크기 (마지막 Part 제외)
+	MaxPartCount = 10000                // S3 Multipart Upload 최대 Part 수
+)
+
+var (
+	ErrInvalidETag     = errors.New("invalid etag")      // ETag 형식이 올바르지 않은 경우
+	ErrInvalidPartSize = errors.New("invalid part size") // Part 크기가 올바르지 않은 경우
+)
+
+// commonPartSizes - 주요 S3 클라이언트들이 사용하는 Part 크기 (가능성이 높은 순서)
+var commonPartSizes = []int64{
+	8 * bytefmt.MEGABYTE,  // aws-cli, boto3 기본값
+	16 * bytefmt.MEGABYTE, // minio-go, mc 기본값
+	5 * bytefmt.MEGABYTE,  // S3 최소값
+	64 * bytefmt.MEGABYTE, // rclone, s3cmd 대용량
+	15 * bytefmt.MEGABYTE, // s3cmd 기본값
+	10 * bytefmt.MEGABYTE,
+	32 * bytefmt.MEGABYTE,
+	100 * bytefmt.MEGABYTE,
+	128 * bytefmt.MEGABYTE,
+	25 * bytefmt.MEGABYTE,
+	50 * bytefmt.MEGABYTE,
+	256 * bytefmt.MEGABYTE,
+	512 * bytefmt.MEGABYTE,
+	1024 * bytefmt.MEGABYTE,
+}
+
+// ===== [ Private Functions ] =====
+
+// formatMultipartETag - Part 별 MD5 들로 Multipart ETag 문자열 구성
+func formatMultipartETag(partSums [][]byte) string {
+	h := md5.New()
+	for _, sum := range partSums {
+		h.Write(sum)
+	}
+	return hex.EncodeToString(h.Sum(nil)) + "-" + strconv.Itoa(len(partSums))
+}
+
+// ===== [ Public Functions ] =====
+
+// PartCount - 지정한 크기를 지정한 Part 크기로 나눈 Part 수 반환
+// conditions:
+// - 크기가 0 인 경우도 1 개의 Part 로 계산한다.
+func PartCount(size, partSize int64) int {
+	if partSize <= 0 {
+		return 0
+	}
+	if size <= 0 {
+		return 1
+	}
+	return int((size + partSize - 1) / partSize)
+}
+
+// MultipartETag - 지정한 Reader 의 내용을 지정한 Part 크기로 나누어 S3 Multipart ETag (`<hex>-<parts>`) 계산
+// conditions:
+// - ETag 는 각 Part MD5 를 연결한 값의 MD5 에 Part 수를 붙인 형식이다.
+// - Multipart 로 업로드된 경우는 Part 가 하나여도 `-1` 이 붙는다.
+func MultipartETag(reader io.Reader, partSize int64) (string, error) {
+	if partSize <= 0 {
+		return "", ErrInvalidPartSize
+	}
+
+	var partSums [][]byte
+	h := md5.New()
+	buf := make([]byte, copyBufferSize)
+	for {
+		h.Reset()
+		n, err := io.CopyBuffer(h, io.LimitReader(reader, partSize), buf)
+		if err != nil {
+			return "", err
+		}
+		if n == 0 && len(partSums) > 0 {
+			break
+		}
+		partSums = append(partSums, h.Sum(nil))
+		if n < partSize {
+			break
+		}
+	}
+
+	return formatMultipartETag(partSums), nil
+}
+
+// ParseETag - 지정한 ETag 를 MD5 Digest 와 Part 수로 분리
+// conditions:
+// - 앞뒤의 따옴표와 약한 ETag 접두어 (`W/`) 는 제거한다.
+// - Multipart 형식이 아닌 경우는 Part 수로 0 반환
+func ParseETag(etag string) ([]byte, int, error) {
+	etag = strings.Trim(strings.TrimPrefix(strings.TrimSpace(etag), "W/"), `"`)
+
+	digest, parts := etag, 0
+	if idx := strings.IndexByte(etag, '-'); idx >= 0 {
+		n, err := strconv.Atoi(etag[idx+1:])
+		if err != nil || n < 1 || n > MaxPartCount {
+			return nil, 0, fmt.Errorf("%w: %s", ErrInvalidETag, etag)
+		}
+		digest, parts = etag[:idx], n
+	}
+
+	sum, err := hex.DecodeString(digest)
+	if err != nil || len(sum) != md5.Size {
+		return nil, 0, fmt.Errorf("%w: %s", ErrInvalidETag, etag)
+	}
+	return sum, parts, nil
+}
+
+// GuessPartSizes - 지정한 ETag 와 파일 크기로 업로드에 사용되었을 가능성이 있는 Part 크기들을 가능성이 높은 순서로 반환
+// conditions:
+// - 주요 클라이언트의 기본 Part 크기 중 Part 수가 일치하는 것을 먼저 반환하고, 마지막으로 MiB 단위로 정렬된 최소 Part 크기를 반환한다.
+// - Multipart ETag 가 아닌 경우는 파일 크기 하나만 반환한다.
+func GuessPartSizes(etag string, size int64) ([]int64, error) {
+	_, parts, err := ParseETag(etag)
+	if err != nil {
+		return nil, err
+	}
+	if parts == 0 {
+		return []int64{size}, nil
+	}
+
+	var candidates []int64
+	seen := map[int64]bool{}
+	add := func(partSize int64) {
+		if partSize <= 0 || seen[partSize] || PartCount(size, partSize) != parts {
+			return
+		}
+		if parts > 1 && partSize < MinPartSize {
+			return
+		}
+		seen[partSize] = true
+		candidates = append(candidates, partSize)
+	}
+
+	for _, partSize := range commonPartSizes {
+		add(partSize)
+	}
+
+	// Part 수를 만족하는 MiB 단위의 최소 Part 크기
+	minSize := (size + int64(parts) - 1) / int64(parts)
+	add((minSize + bytefmt.MEGABYTE - 1) / bytefmt.MEGABYTE * bytefmt.MEGABYTE)
+	add(minSize)
+
+	return candidates, nil
+}
+
+// VerifyETag - 지정한 내용이 지정한 ETag 와 일치하는지 검증하고 일치한 Part 크기 반환
+// conditions:
+// - Multipart ETag 인 경우는 GuessPartSizes 의 후보들을 순서대로 계산하므로 후보 수만큼 내용을 다시 읽는다.
+// - 일치하는 후보가 없는 경우는 false 반환
+func VerifyETag(reader io.ReaderAt, size int64, etag string) (int64, bool, error) {
+	sum, parts, err := ParseETag(etag)
+	if err != nil {
+		return 0, false, err
+	}
+
+	if parts == 0 {
+		h := md5.New()
+		if _, err := io.CopyBuffer(h, io.NewSectionReader(reader, 0, size), make([]byte, copyBufferSize)); err != nil {
+			return 0, false, err
+		}
+		return size, bytes.Equal(h.Sum(nil), sum), nil
+	}
+
+	candidates, err := GuessPartSizes(etag, size)
+	if err != nil {
+		return 0, false, err
+	}
+
+	expected := hex.EncodeToString(sum) + "-" + strconv.Itoa(parts)
+	for _, partSize := range candidates {
+		actual, err := MultipartETag(io.NewSectionReader(reader, 0, size), partSize)
+		if err != nil {
+			return 0, false, err
+		}
+		if actual == expected {
+			return partSize, true, nil
+		}
+	}
+	return 0, false, nil
+}
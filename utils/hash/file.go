@@ -0,0 +1,248 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package hash
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sync"
+
+	"code.cloudfoundry.org/bytefmt"
+)
+
+// ===== [ Constants and Variables ] =====
+const (
+	DefaultFileBufferSize = 1 * bytefmt.MEGABYTE  // 파일을 읽을 때 사용할 기본 버퍼 크기
+	DefaultChunkSize      = 64 * bytefmt.MEGABYTE // 병렬 처리할 기본 Chunk 크기
+)
+
+var ()
+
+// ========== [ FileHashOptions START ] =========
+
+// ProgressFunc - 진행 상황을 전달받는 함수 (처리한 바이트 수, 전체 바이트 수)
+type ProgressFunc func(done, total int64)
+
+// FileHashOptions - 파일 Hash 처리 옵션
+type FileHashOptions struct {
+	Algorithm  Algorithm    // Hash 알고리즘 (빈 값이면 SHA256)
+	BufferSize int          // 읽기 버퍼 크기 (0 이하면 DefaultFileBufferSize)
+	Workers    int          // Chunk 를 병렬로 Hash 할 작업자 수 (0 이하면 runtime.NumCPU())
+	Progress   ProgressFunc // 진행 상황을 전달받을 함수 (nil 이면 전달하지 않음)
+}
+
+// normalize - 기본값 적용 및 검증
+func (o *FileHashOptions) normalize() error {
+	if o.Algorithm == "" {
+		o.Algorithm = SHA256
+	}
+	if !o.Algorithm.Available() {
+		return fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, o.Algorithm)
+	}
+	if o.BufferSize <= 0 {
+		o.BufferSize = DefaultFileBufferSize
+	}
+	if o.Workers <= 0 {
+		o.Workers = runtime.NumCPU()
+	}
+	return nil
+}
+
+// ========== [ FileHashOptions END ] =========
+
+// ========== [ progressTracker START ] =========
+
+// progressTracker - 여러 작업자의 진행 상황을 합산해서 전달
+// conditions:
+// - ProgressFunc 는 동시에 호출되지 않도록 직렬화된다.
+type progressTracker struct {
+	mu    sync.Mutex
+	fn    ProgressFunc
+	done  int64
+	total int64
+}
+
+// add - 처리한 바이트 수 반영
+func (p *progressTracker) add(n int) {
+	if p.fn == nil || n <= 0 {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done += int64(n)
+	p.fn(p.done, p.total)
+}
+
+// ========== [ progressTracker END ] =========
+
+// ========== [ contextReader START ] =========
+
+// contextReader - 읽을 때마다 Context 취소 여부를 확인하고 진행 상황을 반영하는 Reader
+type contextReader struct {
+	ctx      context.Context
+	reader   io.Reader
+	progress *progressTracker
+}
+
+// Read - Context 가 취소된 경우는 Context 오류 반환
+func (r *contextReader) Read(b []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	n, err := r.reader.Read(b)
+	r.progress.add(n)
+	return n, err
+}
+
+// ========== [ contextReader END ] =========
+
+// ===== [ Private Functions ] =====
+
+// openFile - 파일을 열고 크기 반환
+func openFile(path string) (*os.File, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, 0, err
+	}
+	return f, info.Size(), nil
+}
+
+// hashChunks - 지정한 파일을 Chunk 단위로 나누어 병렬로 Hash
+// conditions:
+// - 오류가 발생하거나 Context 가 취소되면 남은 작업을 중단하고 첫번째 오류를 반환한다.
+func hashChunks(ctx context.Context, path string, chunkSize int64, opts FileHashOptions) ([][]byte, error) {
+	f, size, err := openFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	count := PartCount(size, chunkSize)
+	sums := make([][]byte, count)
+	progress := &progressTracker{fn: opts.Progress, total: size}
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	jobs := make(chan int)
+	for i := 0; i < opts.Workers && i < count; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			buf := make([]byte, opts.BufferSize)
+			for idx := range jobs {
+				h, err := opts.Algorithm.New()
+				if err == nil {
+					section := io.NewSectionReader(f, int64(idx)*chunkSize, chunkSize)
+					_, err = io.CopyBuffer(h, &contextReader{ctx: ctx, reader: section, progress: progress}, buf)
+				}
+				if err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					continue
+				}
+				sums[idx] = h.Sum(nil)
+			}
+		}()
+	}
+
+feed:
+	for idx := 0; idx < count; idx++ {
+		select {
+		case jobs <- idx:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	// 작업 배정 중에 부모 Context 가 취소된 경우
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return sums, nil
+}
+
+// ===== [ Public Functions ] =====
+
+// HashFile - 지정한 파일의 Digest 를 Hex 문자열로 반환
+// conditions:
+// - 전체 파일 Digest 는 순차적으로만 계산할 수 있으므로 큰 버퍼를 사용해서 하나의 작업자로 처리한다.
+// - 버퍼를 읽을 때마다 Context 취소 여부를 확인하고 진행 상황을 전달한다.
+func HashFile(ctx context.Context, path string, opts FileHashOptions) (string, error) {
+	if err := opts.normalize(); err != nil {
+		return "", err
+	}
+
+	f, size, err := openFile(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h, err := opts.Algorithm.New()
+	if err != nil {
+		return "", err
+	}
+	reader := &contextReader{ctx: ctx, reader: f, progress: &progressTracker{fn: opts.Progress, total: size}}
+	if _, err := io.CopyBuffer(h, reader, make([]byte, opts.BufferSize)); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// HashFileChunks - 지정한 파일을 지정한 크기의 Chunk 로 나누어 Chunk 별 Digest 를 병렬로 계산
+// conditions:
+// - Chunk 크기가 0 이하면 DefaultChunkSize 를 사용한다.
+// - 반환하는 Digest 들은 파일 내 Chunk 순서와 같으며, 빈 파일은 하나의 빈 Chunk 로 계산한다.
+// - 진행 상황은 여러 작업자의 합산으로 전달된다.
+func HashFileChunks(ctx context.Context, path string, chunkSize int64, opts FileHashOptions) ([][]byte, error) {
+	if err := opts.normalize(); err != nil {
+		return nil, err
+	}
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+	return hashChunks(ctx, path, chunkSize, opts)
+}
+
+// MultipartETagFile - 지정한 파일의 S3 Multipart ETag 를 Part 단위로 병렬 계산
+// conditions:
+// - MultipartETag 와 같은 결과를 반환하며, 옵션의 알고리즘은 무시하고 MD5 를 사용한다.
+func MultipartETagFile(ctx context.Context, path string, partSize int64, opts FileHashOptions) (string, error) {
+	if partSize <= 0 {
+		return "", ErrInvalidPartSize
+	}
+	opts.Algorithm = MD5
+	if err := opts.normalize(); err != nil {
+		return "", err
+	}
+
+	sums, err := hashChunks(ctx, path, partSize, opts)
+	if err != nil {
+		return "", err
+	}
+	return formatMultipartETag(sums), nil
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package hash
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+// ===== [ Constants and Variables ] =====
+const (
+	benchFileSize = 64 << 20 // 벤치마크용 파일 크기
+)
+
+var ()
+
+// ===== [ Private Functions ] =====
+
+// writeTempFile - 지정한 크기의 임의 내용으로 임시 파일을 생성하고 경로와 내용 반환 (크기가 같으면 내용도 같음)
+func writeTempFile(tb testing.TB, size int) (string, []byte) {
+	data := make([]byte, size)
+	rand.New(rand.NewSource(int64(size))).Read(data)
+
+	path := filepath.Join(tb.TempDir(), "data")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		tb.Fatal(err)
+	}
+	return path, data
+}
+
+// ===== [ Public Functions ] =====
+
+func TestMultipartETagFileMatchesMultipartETag(t *testing.T) {
+	const partSize = 64 << 10
+	for _, size := range []int{0, 1, partSize - 1, partSize, partSize + 1, 3 * partSize, 5*partSize + 123} {
+		path, data := writeTempFile(t, size)
+
+		expected, err := MultipartETag(bytes.NewReader(data), partSize)
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual, err := MultipartETagFile(context.Background(), path, partSize, FileHashOptions{BufferSize: 4 << 10, Workers: 4})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual != expected {
+			t.Errorf("size %d: MultipartETagFile = %s, MultipartETag = %s", size, actual, expected)
+		}
+	}
+}
+
+func TestHashFileChunksCancel(t *testing.T) {
+	path, _ := writeTempFile(t, 4<<20)
+
+	// 이미 취소된 Context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := HashFileChunks(ctx, path, 64<<10, FileHashOptions{}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("HashFileChunks with cancelled context returned %v, expected context.Canceled", err)
+	}
+
+	// 처리 중에 취소된 Context
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	var done int64
+	opts := FileHashOptions{
+		BufferSize: 4 << 10,
+		Workers:    2,
+		Progress: func(n, total int64) {
+			atomic.StoreInt64(&done, n)
+			cancel()
+		},
+	}
+	sums, err := HashFileChunks(ctx, path, 64<<10, opts)
+	if !errors.Is(err, context.Canceled) || sums != nil {
+		t.Fatalf("HashFileChunks cancelled while hashing returned (%d sums, %v), expected context.Canceled", len(sums), err)
+	}
+	if n := atomic.LoadInt64(&done); n >= 4<<20 {
+		t.Fatalf("HashFileChunks read %d bytes after cancel, expected to stop early", n)
+	}
+}
+
+func BenchmarkGetMD5(b *testing.B) {
+	path, _ := writeTempFile(b, benchFileSize)
+
+	b.SetBytes(benchFileSize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f, err := os.Open(path)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := GetMD5(f); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkHashFile(b *testing.B) {
+	path, _ := writeTempFile(b, benchFileSize)
+
+	b.SetBytes(benchFileSize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := HashFile(context.Background(), path, FileHashOptions{Algorithm: MD5}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkHashFileChunks(b *testing.B) {
+	path, _ := writeTempFile(b, benchFileSize)
+
+	b.SetBytes(benchFileSize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := HashFileChunks(context.Background(), path, 8<<20, FileHashOptions{Algorithm: MD5}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
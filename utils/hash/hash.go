@@ -0,0 +1,58 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package hash
+
+import (
+	"io"
+
+	"code.cloudfoundry.org/bytefmt"
+)
+
+// ===== [ Constants and Variables ] =====
+const (
+	copyBufferSize = 32 * bytefmt.KILOBYTE // Reader 를 읽을 때 사용할 버퍼 크기
+)
+
+var ()
+
+// ===== [ Private Functions ] =====
+// ===== [ Public Functions ] =====
+
+// GetHash - 지정한 Reader의 값을 지정한 알고리즘의 Digest로 변환해서 Hex 문자열로 반환
+// conditions:
+// - Reader는 끝까지 읽지만 Close 하지 않는다.
+func GetHash(reader io.Reader, algorithm Algorithm) (string, error) {
+	sums, err := GetHashes(reader, algorithm)
+	if err != nil {
+		return "", err
+	}
+	return sums[algorithm], nil
+}
+
+// GetHashes - 지정한 Reader의 값을 한번만 읽어서 지정한 알고리즘들의 Digest를 Hex 문자열로 반환
+// conditions:
+// - Reader는 끝까지 읽지만 Close 하지 않는다.
+func GetHashes(reader io.Reader, algorithms ...Algorithm) (map[Algorithm]string, error) {
+	m, err := NewMultiHasher(algorithms...)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, copyBufferSize)
+	if _, err := io.CopyBuffer(m, reader, buf); err != nil {
+		return nil, err
+	}
+
+	return m.HexSums(), nil
+}
+
+// Mix64 - splitmix64 finalizer 로 Bit 분산 (Sketch 와 shard 패키지의 Hash 보정용)
+func Mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,331 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package hash
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ===== [ Constants and Variables ] =====
+const (
+	DefaultSignatureHeader = "X-Signature"           // 서명을 전달하는 기본 Header
+	DefaultTimestampHeader = "X-Signature-Timestamp" // 서명 시각 (Unix 초) 을 전달하는 기본 Header
+	DefaultMaxClockSkew    = 5 * time.Minute         // 허용하는 기본 시각 차이
+	DefaultMaxBodySize     = 10 << 20                // 검증 시 허용하는 기본 Body 크기 (10 MiB)
+)
+
+var (
+	ErrEmptyKey          = errors.New("hmac key is empty")   // 서명 키가 지정되지 않은 경우
+	ErrMissingSignature  = errors.New("missing signature")   // 서명 또는 서명 시각이 없는 경우
+	ErrInvalidSignature  = errors.New("invalid signature")   // 서명이 일치하지 않는 경우
+	ErrSignatureExpired  = errors.New("signature expired")   // 서명 시각이 허용 범위를 벗어난 경우
+	ErrSignatureReplayed = errors.New("signature replayed")  // 이미 사용된 서명인 경우
+	ErrBodyTooLarge      = errors.New("body too large")      // Body 가 MaxBodySize 를 초과한 경우
+	ErrInvalidHMACOption = errors.New("invalid hmac option") // 검증 옵션이 올바르지 않은 경우
+)
+
+// ========== [ HMACOptions START ] =========
+
+// HMACOptions - HMAC-SHA256 요청 서명/검증 옵션
+type HMACOptions struct {
+	Key             []byte           // 서명 키
+	SignedHeaders   []string         // 서명에 포함할 Header 이름들 (Host 는 요청의 Host 를 사용)
+	SignatureHeader string           // 서명 Header 이름 (빈 값이면 DefaultSignatureHeader)
+	TimestampHeader string           // 서명 시각 Header 이름 (빈 값이면 DefaultTimestampHeader)
+	MaxClockSkew    time.Duration    // 검증 시 허용하는 시각 차이 (0 이하면 DefaultMaxClockSkew)
+	ReplayWindow    time.Duration    // 검증 시 같은 서명의 재사용을 거부하는 기간, MaxClockSkew 의 2 배 이상이어야 함 (0 이면 MaxClockSkew 의 2 배, 음수면 사용 안함)
+	MaxBodySize     int64            // 검증 시 허용하는 Body 크기 (0 이면 DefaultMaxBodySize, 음수면 제한 없음)
+	Clock           func() time.Time // 현재 시각 (nil 이면 time.Now)
+}
+
+// normalize - 기본값 적용 및 검증
+func (o *HMACOptions) normalize() error {
+	if len(o.Key) == 0 {
+		return ErrEmptyKey
+	}
+	if o.SignatureHeader == "" {
+		o.SignatureHeader = DefaultSignatureHeader
+	}
+	if o.TimestampHeader == "" {
+		o.TimestampHeader = DefaultTimestampHeader
+	}
+	if o.MaxClockSkew <= 0 {
+		o.MaxClockSkew = DefaultMaxClockSkew
+	}
+	if o.ReplayWindow == 0 {
+		o.ReplayWindow = 2 * o.MaxClockSkew
+	}
+	if o.MaxBodySize == 0 {
+		o.MaxBodySize = DefaultMaxBodySize
+	}
+	if o.Clock == nil {
+		o.Clock = time.Now
+	}
+
+	headers := make([]string, 0, len(o.SignedHeaders))
+	for _, name := range o.SignedHeaders {
+		headers = append(headers, strings.ToLower(strings.TrimSpace(name)))
+	}
+	sort.Strings(headers)
+	o.SignedHeaders = headers
+	return nil
+}
+
+// sign - 지정한 요청과 Body, 서명 시각으로 서명 계산
+func (o *HMACOptions) sign(req *http.Request, body []byte, timestamp string) []byte {
+	mac := hmac.New(sha256.New, o.Key)
+	io.WriteString(mac, CanonicalRequest(req, o.SignedHeaders, body, timestamp))
+	return mac.Sum(nil)
+}
+
+// ========== [ HMACOptions END ] =========
+
+// ========== [ HMACSigner START ] =========
+
+// HMACSigner - HMAC-SHA256 요청 서명 처리용
+type HMACSigner struct {
+	opts HMACOptions
+}
+
+// Sign - 지정한 요청에 서명 시각과 서명 Header 를 설정
+// conditions:
+// - Body 는 끝까지 읽은 후 다시 읽을 수 있도록 복원한다.
+func (s *HMACSigner) Sign(req *http.Request) error {
+	body, err := readBody(req, -1)
+	if err != nil {
+		return err
+	}
+	timestamp := strconv.FormatInt(s.opts.Clock().Unix(), 10)
+	sum := s.opts.sign(req, body, timestamp)
+
+	req.Header.Set(s.opts.TimestampHeader, timestamp)
+	req.Header.Set(s.opts.SignatureHeader, hex.EncodeToString(sum))
+	return nil
+}
+
+// ========== [ HMACSigner END ] =========
+
+// ========== [ HMACVerifier START ] =========
+
+// HMACVerifier - HMAC-SHA256 요청 서명 검증 처리용
+// conditions:
+// - 동시 사용에 안전하다.
+type HMACVerifier struct {
+	opts HMACOptions
+
+	mu        sync.Mutex
+	seen      map[string]time.Time // 사용된 서명별 만료 시각
+	lastSweep time.Time
+}
+
+// Verify - 지정한 요청의 서명 검증
+// conditions:
+// - 서명 시각이 현재 시각과 MaxClockSkew 이상 차이나면 ErrSignatureExpired
+// - 서명 비교는 상수 시간으로 처리한다.
+// - 서명이 일치한 경우에만 재사용 여부를 확인하고 기록한다.
+// - Body 는 끝까지 읽은 후 다시 읽을 수 있도록 복원하며, MaxBodySize 를 초과하면 ErrBodyTooLarge
+func (v *HMACVerifier) Verify(req *http.Request) error {
+	signature := req.Header.Get(v.opts.SignatureHeader)
+	timestamp := req.Header.Get(v.opts.TimestampHeader)
+	if signature == "" || timestamp == "" {
+		return ErrMissingSignature
+	}
+
+	sec, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: invalid timestamp %q", ErrInvalidSignature, timestamp)
+	}
+	now := v.opts.Clock()
+	skew := now.Sub(time.Unix(sec, 0))
+	if skew > v.opts.MaxClockSkew || skew < -v.opts.MaxClockSkew {
+		return ErrSignatureExpired
+	}
+
+	actual, err := hex.DecodeString(signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	body, err := readBody(req, v.opts.MaxBodySize)
+	if err != nil {
+		return err
+	}
+	expected := v.opts.sign(req, body, timestamp)
+	if !hmac.Equal(actual, expected) {
+		return ErrInvalidSignature
+	}
+
+	if v.opts.ReplayWindow > 0 {
+		return v.remember(string(expected), now)
+	}
+	return nil
+}
+
+// Middleware - 서명이 검증된 요청만 다음 Handler 로 전달하는 Middleware
+// conditions:
+// - Body 는 http.MaxBytesReader 로 MaxBodySize 까지만 읽으며, 초과하면 413 Request Entity Too Large 로 응답한다.
+// - 그 외 검증에 실패하면 401 Unauthorized 로 응답한다.
+func (v *HMACVerifier) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if v.opts.MaxBodySize > 0 && r.Body != nil && r.Body != http.NoBody {
+			r.Body = http.MaxBytesReader(w, r.Body, v.opts.MaxBodySize)
+		}
+		if err := v.Verify(r); err != nil {
+			if errors.Is(err, ErrBodyTooLarge) {
+				http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+				return
+			}
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// remember - 서명 사용 기록, 이미 사용된 서명이면 ErrSignatureReplayed
+func (v *HMACVerifier) remember(key string, now time.Time) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	// 만료된 기록 정리
+	if now.Sub(v.lastSweep) >= v.opts.ReplayWindow/2 {
+		for k, expiry := range v.seen {
+			if !now.Before(expiry) {
+				delete(v.seen, k)
+			}
+		}
+		v.lastSweep = now
+	}
+
+	if expiry, ok := v.seen[key]; ok && now.Before(expiry) {
+		return ErrSignatureReplayed
+	}
+	v.seen[key] = now.Add(v.opts.ReplayWindow)
+	return nil
+}
+
+// ========== [ HMACVerifier END ] =========
+
+// ===== [ Private Functions ] =====
+
+// readBody - 요청 Body 를 읽고 다시 읽을 수 있도록 복원
+// conditions:
+// - limit 이 0 보다 크면 limit 까지만 읽고, 초과하면 ErrBodyTooLarge
+// - http.MaxBytesReader 로 제한된 Body 는 limit 에 도달한 후의 읽기 오류를 ErrBodyTooLarge 로 처리한다.
+func readBody(req *http.Request, limit int64) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	var reader io.Reader = req.Body
+	if limit > 0 {
+		reader = io.LimitReader(req.Body, limit+1)
+	}
+	body, err := io.ReadAll(reader)
+	req.Body.Close()
+	if err != nil {
+		if limit > 0 && int64(len(body)) == limit {
+			return nil, fmt.Errorf("%w: %v", ErrBodyTooLarge, err)
+		}
+		return nil, err
+	}
+	if limit > 0 && int64(len(body)) > limit {
+		return nil, fmt.Errorf("%w: exceeds %d bytes", ErrBodyTooLarge, limit)
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return body, nil
+}
+
+// canonicalQuery - Query 를 키와 값 순으로 정렬해서 인코딩
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var pairs []string
+	for _, k := range keys {
+		values := append([]string(nil), query[k]...)
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, url.QueryEscape(k)+"="+url.QueryEscape(value))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// ===== [ Public Functions ] =====
+
+// CanonicalRequest - 서명 대상 문자열 구성
+// conditions:
+// - Method, Path, 정렬된 Query, `name:value` 형식의 Header 들, 서명한 Header 이름들, Body SHA-256, 서명 시각을 개행으로 연결한다.
+// - Header 이름은 소문자로 정렬되며, 여러 값은 앞뒤 공백을 제거하고 ',' 로 연결한다.
+func CanonicalRequest(req *http.Request, signedHeaders []string, body []byte, timestamp string) string {
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(strings.ToUpper(req.Method) + "\n")
+	sb.WriteString(path + "\n")
+	sb.WriteString(canonicalQuery(req.URL.Query()) + "\n")
+
+	for _, name := range signedHeaders {
+		var values []string
+		if name == "host" {
+			values = []string{req.Host}
+		} else {
+			for _, value := range req.Header.Values(name) {
+				values = append(values, strings.TrimSpace(value))
+			}
+		}
+		sb.WriteString(name + ":" + strings.Join(values, ",") + "\n")
+	}
+	sb.WriteString(strings.Join(signedHeaders, ";") + "\n")
+
+	bodySum := sha256.Sum256(body)
+	sb.WriteString(hex.EncodeToString(bodySum[:]) + "\n")
+	sb.WriteString(timestamp)
+	return sb.String()
+}
+
+// NewHMACSigner - 지정한 옵션으로 HMACSigner 생성
+func NewHMACSigner(opts HMACOptions) (*HMACSigner, error) {
+	if err := opts.normalize(); err != nil {
+		return nil, err
+	}
+	return &HMACSigner{opts: opts}, nil
+}
+
+// NewHMACVerifier - 지정한 옵션으로 HMACVerifier 생성
+// conditions:
+// - 서명 측과 같은 Key, SignedHeaders, Header 이름을 사용해야 한다.
+// - ReplayWindow 가 MaxClockSkew 의 2 배보다 작으면, 기록이 만료된 후에도 서명 시각이 허용 범위에 있어 재사용될 수 있으므로 ErrInvalidHMACOption
+func NewHMACVerifier(opts HMACOptions) (*HMACVerifier, error) {
+	if err := opts.normalize(); err != nil {
+		return nil, err
+	}
+	if opts.ReplayWindow > 0 && opts.ReplayWindow < 2*opts.MaxClockSkew {
+		return nil, fmt.Errorf("%w: replay window %v is shorter than twice the max clock skew %v", ErrInvalidHMACOption, opts.ReplayWindow, opts.MaxClockSkew)
+	}
+	return &HMACVerifier{opts: opts, seen: map[string]time.Time{}}, nil
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package hash
+
+import (
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+)
+
+// ===== [ Constants and Variables ] =====
+const ()
+
+var (
+	ErrNoAlgorithm = errors.New("no hash algorithm specified") // 알고리즘을 지정하지 않은 경우
+)
+
+// ========== [ MultiHasher START ] =========
+
+// MultiHasher - 한번의 입력으로 여러 알고리즘의 Digest 를 계산하는 io.Writer 정보 관리용
+type MultiHasher struct {
+	algorithms []Algorithm
+	hashes     []hash.Hash
+}
+
+// Write - 지정한 내용을 모든 알고리즘에 반영
+func (m *MultiHasher) Write(p []byte) (int, error) {
+	for _, h := range m.hashes {
+		h.Write(p) // hash.Hash 의 Write 는 오류를 반환하지 않는다.
+	}
+	return len(p), nil
+}
+
+// Algorithms - 계산 중인 알고리즘 목록 반환
+func (m *MultiHasher) Algorithms() []Algorithm {
+	return append([]Algorithm(nil), m.algorithms...)
+}
+
+// Sum - 지정한 알고리즘의 Digest 반환
+// conditions:
+// - 계산 중이 아닌 알고리즘인 경우는 nil 반환
+func (m *MultiHasher) Sum(a Algorithm) []byte {
+	for i, alg := range m.algorithms {
+		if alg == a {
+			return m.hashes[i].Sum(nil)
+		}
+	}
+	return nil
+}
+
+// Hex - 지정한 알고리즘의 Digest 를 Hex 문자열로 반환
+func (m *MultiHasher) Hex(a Algorithm) string {
+	return hex.EncodeToString(m.Sum(a))
+}
+
+// Sums - 모든 알고리즘의 Digest 반환
+func (m *MultiHasher) Sums() map[Algorithm][]byte {
+	sums := make(map[Algorithm][]byte, len(m.algorithms))
+	for i, alg := range m.algorithms {
+		sums[alg] = m.hashes[i].Sum(nil)
+	}
+	return sums
+}
+
+// HexSums - 모든 알고리즘의 Digest 를 Hex 문자열로 반환
+func (m *MultiHasher) HexSums() map[Algorithm]string {
+	sums := make(map[Algorithm]string, len(m.algorithms))
+	for i, alg := range m.algorithms {
+		sums[alg] = hex.EncodeToString(m.hashes[i].Sum(nil))
+	}
+	return sums
+}
+
+// Reset - 모든 알고리즘의 상태 초기화
+func (m *MultiHasher) Reset() {
+	for _, h := range m.hashes {
+		h.Reset()
+	}
+}
+
+// ========== [ MultiHasher END ] =========
+
+// ========== [ HashReader START ] =========
+
+// HashReader - 읽은 내용으로 여러 알고리즘의 Digest 를 계산하는 Reader 정보 관리용
+type HashReader struct {
+	*MultiHasher
+	body io.Reader
+}
+
+// Read - 원본 Reader 에서 읽은 내용을 Digest 에 반영하고 크기와 오류를 반환
+func (r *HashReader) Read(b []byte) (int, error) {
+	n, err := r.body.Read(b)
+	if n > 0 {
+		r.MultiHasher.Write(b[:n])
+	}
+	return n, err
+}
+
+// ========== [ HashReader END ] =========
+
+// ========== [ HashWriter START ] =========
+
+// HashWriter - 원본 Writer 로 출력한 내용으로 여러 알고리즘의 Digest 를 계산하는 Writer 정보 관리용 (tee)
+type HashWriter struct {
+	*MultiHasher
+	w io.Writer
+}
+
+// Write - 원본 Writer 로 출력하고 출력된 내용만 Digest 에 반영
+func (w *HashWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	if n > 0 {
+		w.MultiHasher.Write(p[:n])
+	}
+	return n, err
+}
+
+// ========== [ HashWriter END ] =========
+
+// ===== [ Private Functions ] =====
+// ===== [ Public Functions ] =====
+
+// NewMultiHasher - 지정한 알고리즘들의 Digest 를 계산하는 MultiHasher 생성
+// conditions:
+// - 중복된 알고리즘은 한번만 계산한다.
+func NewMultiHasher(algorithms ...Algorithm) (*MultiHasher, error) {
+	if len(algorithms) == 0 {
+		return nil, ErrNoAlgorithm
+	}
+
+	m := &MultiHasher{}
+	for _, alg := range algorithms {
+		if m.Sum(alg) != nil {
+			continue
+		}
+		h, err := alg.New()
+		if err != nil {
+			return nil, err
+		}
+		m.algorithms = append(m.algorithms, alg)
+		m.hashes = append(m.hashes, h)
+	}
+	return m, nil
+}
+
+// NewHashReader - 지정한 Reader 를 기반으로 지정한 알고리즘들의 Digest 를 계산하는 Reader 생성
+func NewHashReader(reader io.Reader, algorithms ...Algorithm) (*HashReader, error) {
+	m, err := NewMultiHasher(algorithms...)
+	if err != nil {
+		return nil, err
+	}
+	return &HashReader{MultiHasher: m, body: reader}, nil
+}
+
+// NewHashWriter - 지정한 Writer 로 출력하면서 지정한 알고리즘들의 Digest 를 계산하는 Writer 생성
+func NewHashWriter(writer io.Writer, algorithms ...Algorithm) (*HashWriter, error) {
+	m, err := NewMultiHasher(algorithms...)
+	if err != nil {
+		return nil, err
+	}
+	return &HashWriter{MultiHasher: m, w: writer}, nil
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package shard
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/ccambo/gocorelib/utils/hash"
+)
+
+// ===== [ Constants and Variables ] =====
+const ()
+
+var ()
+
+// ========== [ Rendezvous START ] =========
+
+// Rendezvous - 가중치를 지원하는 Rendezvous (Highest Random Weight) Hashing 정보 관리용
+// conditions:
+// - 노드 추가/삭제 시 해당 노드가 담당하는 키들만 이동한다.
+// - 조회 비용은 노드 수에 비례하지만 가상 노드가 필요 없다.
+// - 동시에 사용해도 안전하다.
+type Rendezvous struct {
+	mu    sync.RWMutex
+	hash  HashFunc
+	nodes []rendezvousNode
+}
+
+// rendezvousNode - Rendezvous 노드 정보
+type rendezvousNode struct {
+	name   string
+	weight float64
+	hash   uint64
+}
+
+// Add - 지정한 가중치로 노드 추가
+// conditions:
+// - 가중치가 0 이하면 1 로 처리하며, 이미 존재하는 노드는 가중치만 변경된다.
+func (r *Rendezvous) Add(node string, weight int) {
+	if weight <= 0 {
+		weight = 1
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.nodes {
+		if r.nodes[i].name == node {
+			r.nodes[i].weight = float64(weight)
+			return
+		}
+	}
+	r.nodes = append(r.nodes, rendezvousNode{name: node, weight: float64(weight), hash: r.hash([]byte(node))})
+	sort.Slice(r.nodes, func(i, j int) bool { return r.nodes[i].name < r.nodes[j].name })
+}
+
+// Remove - 지정한 노드 제거
+func (r *Rendezvous) Remove(node string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.nodes {
+		if r.nodes[i].name == node {
+			r.nodes = append(r.nodes[:i], r.nodes[i+1:]...)
+			return
+		}
+	}
+}
+
+// Get - 지정한 키에 대해 점수가 가장 높은 노드 반환
+// conditions:
+// - 노드가 없으면 false 반환
+func (r *Rendezvous) Get(key string) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.nodes) == 0 {
+		return "", false
+	}
+
+	kh := r.hash([]byte(key))
+	best, bestScore := 0, math.Inf(-1)
+	for i := range r.nodes {
+		// 동점인 경우는 이름순으로 먼저인 노드 (nodes 는 이름순으로 정렬되어 있음)
+		if score := r.nodes[i].score(kh); score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	return r.nodes[best].name, true
+}
+
+// GetN - 지정한 키에 대해 점수가 높은 순서로 최대 n 개의 노드 반환 (복제본 배치용)
+func (r *Rendezvous) GetN(key string, n int) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.nodes) == 0 || n <= 0 {
+		return nil
+	}
+	if n > len(r.nodes) {
+		n = len(r.nodes)
+	}
+
+	kh := r.hash([]byte(key))
+	scores := make([]float64, len(r.nodes))
+	idx := make([]int, len(r.nodes))
+	for i := range r.nodes {
+		scores[i] = r.nodes[i].score(kh)
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool { return scores[idx[a]] > scores[idx[b]] })
+
+	nodes := make([]string, 0, n)
+	for _, i := range idx[:n] {
+		nodes = append(nodes, r.nodes[i].name)
+	}
+	return nodes
+}
+
+// Nodes - 등록된 노드들을 이름순으로 반환
+func (r *Rendezvous) Nodes() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	nodes := make([]string, 0, len(r.nodes))
+	for _, node := range r.nodes {
+		nodes = append(nodes, node.name)
+	}
+	return nodes
+}
+
+// Len - 등록된 노드 수 반환
+func (r *Rendezvous) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.nodes)
+}
+
+// score - 지정한 키 Hash 에 대한 노드 점수 계산
+// conditions:
+// - 가중치 w 에 대해 -w / ln(u) (u 는 (0, 1) 범위로 정규화된 Hash) 를 사용하면 키가 가중치에 비례해서 분배된다.
+func (n rendezvousNode) score(keyHash uint64) float64 {
+	u := (float64(hash.Mix64(keyHash^n.hash)>>11) + 0.5) / (1 << 53)
+	return -n.weight / math.Log(u)
+}
+
+// ========== [ Rendezvous END ] =========
+
+// ===== [ Private Functions ] =====
+// ===== [ Public Functions ] =====
+
+// NewRendezvous - 지정한 HashFunc 를 사용하는 Rendezvous 생성
+// conditions:
+// - fn 이 nil 이면 DefaultHash 사용
+func NewRendezvous(fn HashFunc) *Rendezvous {
+	if fn == nil {
+		fn = DefaultHash
+	}
+
+	return &Rendezvous{hash: fn}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package shard
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// ===== [ Public Functions ] =====
+
+func TestRendezvousDistribution(t *testing.T) {
+	checkDistribution(t, NewRendezvous(nil), map[string]int{"a": 1, "b": 1, "c": 1, "d": 1}, 0.05)
+}
+
+func TestRendezvousWeights(t *testing.T) {
+	checkDistribution(t, NewRendezvous(nil), map[string]int{"a": 1, "b": 2, "c": 3}, 0.05)
+}
+
+func TestRendezvousMinimalMovement(t *testing.T) {
+	checkMovement(t, NewRendezvous(nil), 5)
+}
+
+func TestRendezvousOrderIndependence(t *testing.T) {
+	a := NewRendezvous(nil)
+	a.Add("a", 1)
+	a.Add("b", 2)
+	a.Add("c", 1)
+
+	b := NewRendezvous(nil)
+	b.Add("c", 1)
+	b.Add("b", 2)
+	b.Add("a", 1)
+
+	if !reflect.DeepEqual(assignments(t, a), assignments(t, b)) {
+		t.Fatal("rendezvous built in different order assigns keys differently")
+	}
+}
+
+func TestRendezvousGetN(t *testing.T) {
+	r := NewRendezvous(nil)
+	r.Add("a", 1)
+	r.Add("b", 1)
+	r.Add("c", 1)
+
+	nodes := r.GetN("key", 2)
+	if len(nodes) != 2 || nodes[0] == nodes[1] {
+		t.Fatalf("GetN returned %v, expected 2 distinct nodes", nodes)
+	}
+	if first, _ := r.Get("key"); nodes[0] != first {
+		t.Fatalf("GetN first node %s, expected Get result %s", nodes[0], first)
+	}
+}
+
+func BenchmarkRendezvousGet(b *testing.B) {
+	for _, nodes := range []int{3, 16, 128} {
+		b.Run(fmt.Sprintf("nodes=%d", nodes), func(b *testing.B) {
+			benchmarkGet(b, NewRendezvous(nil), nodes)
+		})
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+
+// shard - Consistent Hash Ring 및 Rendezvous (HRW) Hashing 기반의 작업 분배 기능 제공 패키지
+package shard
+
+import (
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/ccambo/gocorelib/utils/hash"
+)
+
+// ===== [ Constants and Variables ] =====
+const (
+	DefaultReplicas = 160 // 가중치 1 당 기본 가상 노드 수
+)
+
+var ()
+
+// ========== [ Ring START ] =========
+
+// HashFunc - 키를 64비트 값으로 변환하는 함수
+type HashFunc func(data []byte) uint64
+
+// Ring - 가상 노드와 가중치를 지원하는 Consistent Hash Ring 정보 관리용
+// conditions:
+// - 노드 추가/삭제 시 해당 노드가 담당하는 키들만 이동한다.
+// - 동시에 사용해도 안전하다.
+type Ring struct {
+	mu       sync.RWMutex
+	replicas int
+	hash     HashFunc
+	weights  map[string]int
+	points   []uint64
+	owners   map[uint64]string
+}
+
+// Add - 지정한 가중치로 노드 추가
+// conditions:
+// - 가중치가 0 이하면 1 로 처리하며, 이미 존재하는 노드는 가중치만 변경된다.
+// - 가상 노드 수는 replicas * weight 이다.
+func (r *Ring) Add(node string, weight int) {
+	if weight <= 0 {
+		weight = 1
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.weights[node] = weight
+	r.rebuild()
+}
+
+// Remove - 지정한 노드 제거
+func (r *Ring) Remove(node string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.weights[node]; !ok {
+		return
+	}
+	delete(r.weights, node)
+	r.rebuild()
+}
+
+// Get - 지정한 키를 담당하는 노드 반환
+// conditions:
+// - 노드가 없으면 false 반환
+func (r *Ring) Get(key string) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.points) == 0 {
+		return "", false
+	}
+	return r.owners[r.points[r.search(key)]], true
+}
+
+// GetN - 지정한 키를 담당하는 서로 다른 노드들을 Ring 순서대로 최대 n 개 반환 (복제본 배치용)
+func (r *Ring) GetN(key string, n int) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.points) == 0 || n <= 0 {
+		return nil
+	}
+	if n > len(r.weights) {
+		n = len(r.weights)
+	}
+
+	nodes := make([]string, 0, n)
+	seen := make(map[string]bool, n)
+	for i, idx := 0, r.search(key); i < len(r.points) && len(nodes) < n; i++ {
+		owner := r.owners[r.points[(idx+i)%len(r.points)]]
+		if !seen[owner] {
+			seen[owner] = true
+			nodes = append(nodes, owner)
+		}
+	}
+	return nodes
+}
+
+// Nodes - 등록된 노드들을 이름순으로 반환
+func (r *Ring) Nodes() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return sortedNodes(r.weights)
+}
+
+// Len - 등록된 노드 수 반환
+func (r *Ring) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.weights)
+}
+
+// search - 지정한 키의 Hash 이상인 첫번째 가상 노드 위치 반환 (없으면 처음으로 순환)
+func (r *Ring) search(key string) int {
+	h := r.hash([]byte(key))
+	idx := sort.Search(len(r.points), func(i int) bool { return r.points[i] >= h })
+	if idx == len(r.points) {
+		idx = 0
+	}
+	return idx
+}
+
+// rebuild - 등록된 노드들로 가상 노드 재구성
+// conditions:
+// - 가상 노드 위치는 노드 이름과 순번으로만 결정되므로 추가 순서와 무관하게 동일한 Ring 이 구성된다.
+// - 위치가 충돌하면 이름이 작은 노드가 차지한다.
+func (r *Ring) rebuild() {
+	r.points = r.points[:0]
+	r.owners = make(map[uint64]string, len(r.owners))
+
+	for _, node := range sortedNodes(r.weights) {
+		for i := 0; i < r.replicas*r.weights[node]; i++ {
+			point := r.hash([]byte(node + "#" + strconv.Itoa(i)))
+			if _, ok := r.owners[point]; ok {
+				continue
+			}
+			r.owners[point] = node
+			r.points = append(r.points, point)
+		}
+	}
+	sort.Slice(r.points, func(i, j int) bool { return r.points[i] < r.points[j] })
+}
+
+// ========== [ Ring END ] =========
+
+// ===== [ Private Functions ] =====
+
+// sortedNodes - 노드 이름들을 정렬해서 반환
+func sortedNodes(weights map[string]int) []string {
+	nodes := make([]string, 0, len(weights))
+	for node := range weights {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+// ===== [ Public Functions ] =====
+
+// DefaultHash - FNV-1a 64 에 비트 분산을 적용한 기본 HashFunc
+func DefaultHash(data []byte) uint64 {
+	h := fnv.New64a()
+	h.Write(data)
+	return hash.Mix64(h.Sum64())
+}
+
+// NewRing - 지정한 가상 노드 수와 HashFunc 를 사용하는 Ring 생성
+// conditions:
+// - replicas 가 0 이하면 DefaultReplicas, fn 이 nil 이면 DefaultHash 사용
+func NewRing(replicas int, fn HashFunc) *Ring {
+	if replicas <= 0 {
+		replicas = DefaultReplicas
+	}
+	if fn == nil {
+		fn = DefaultHash
+	}
+
+	return &Ring{
+		replicas: replicas,
+		hash:     fn,
+		weights:  make(map[string]int),
+		owners:   make(map[uint64]string),
+	}
+}
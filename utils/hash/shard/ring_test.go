@@ -0,0 +1,190 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package shard
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// ===== [ Constants and Variables ] =====
+const (
+	testKeyCount = 100000 // 분배 검증에 사용하는 키 수
+)
+
+var ()
+
+// ===== [ Types ] =====
+type (
+	// sharder - Ring 과 Rendezvous 의 공통 검증용
+	sharder interface {
+		Add(node string, weight int)
+		Remove(node string)
+		Get(key string) (string, bool)
+	}
+)
+
+// ===== [ Private Functions ] =====
+
+// testKey - 검증용 키 생성
+func testKey(i int) string {
+	return "key-" + strconv.Itoa(i)
+}
+
+// assignments - 검증용 키들의 담당 노드 반환
+func assignments(t testing.TB, s sharder) []string {
+	owners := make([]string, testKeyCount)
+	for i := range owners {
+		node, ok := s.Get(testKey(i))
+		if !ok {
+			t.Fatalf("Get(%q) returned no node", testKey(i))
+		}
+		owners[i] = node
+	}
+	return owners
+}
+
+// checkDistribution - 노드별 키 수가 가중치 비율에서 tolerance 이내인지 검증
+func checkDistribution(t *testing.T, s sharder, weights map[string]int, tolerance float64) {
+	total := 0
+	for node, weight := range weights {
+		s.Add(node, weight)
+		total += weight
+	}
+
+	counts := map[string]int{}
+	for _, node := range assignments(t, s) {
+		counts[node]++
+	}
+	for node, weight := range weights {
+		expected := float64(testKeyCount) * float64(weight) / float64(total)
+		if diff := math.Abs(float64(counts[node])-expected) / expected; diff > tolerance {
+			t.Errorf("node %s got %d keys, expected %.0f (±%.0f%%), diff %.1f%%", node, counts[node], expected, tolerance*100, diff*100)
+		}
+	}
+}
+
+// checkMovement - 노드 추가/삭제 시 해당 노드와 관련된 키들만 이동하는지 검증
+func checkMovement(t *testing.T, s sharder, nodes int) {
+	for i := 0; i < nodes; i++ {
+		s.Add(fmt.Sprintf("node-%d", i), 1)
+	}
+	before := assignments(t, s)
+
+	// 추가된 노드로만 이동하고, 이동량은 약 1/(nodes+1)
+	added := fmt.Sprintf("node-%d", nodes)
+	s.Add(added, 1)
+	after := assignments(t, s)
+	moved := 0
+	for i := range before {
+		if before[i] == after[i] {
+			continue
+		}
+		moved++
+		if after[i] != added {
+			t.Fatalf("key %s moved from %s to %s, expected only moves to %s", testKey(i), before[i], after[i], added)
+		}
+	}
+	if ratio, expected := float64(moved)/testKeyCount, 1/float64(nodes+1); ratio > expected*1.25 {
+		t.Errorf("adding a node moved %.1f%% of keys, expected about %.1f%%", ratio*100, expected*100)
+	}
+
+	// 제거된 노드의 키들만 이동하고, 나머지는 추가 전과 동일
+	s.Remove(added)
+	for i, node := range assignments(t, s) {
+		if node != before[i] {
+			t.Fatalf("key %s is on %s after removing %s, expected %s", testKey(i), node, added, before[i])
+		}
+	}
+
+	removed := "node-0"
+	s.Remove(removed)
+	for i, node := range assignments(t, s) {
+		if before[i] != removed && node != before[i] {
+			t.Fatalf("key %s moved from %s to %s after removing %s", testKey(i), before[i], node, removed)
+		}
+	}
+}
+
+// benchmarkGet - 지정한 노드 수로 Get 성능 측정
+func benchmarkGet(b *testing.B, s sharder, nodes int) {
+	for i := 0; i < nodes; i++ {
+		s.Add(fmt.Sprintf("node-%d", i), 1)
+	}
+	keys := make([]string, 1024)
+	for i := range keys {
+		keys[i] = testKey(i)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Get(keys[i%len(keys)])
+	}
+}
+
+// ===== [ Public Functions ] =====
+
+// 가상 노드 160 개의 부하 편차는 약 1/sqrt(160) (8%) 이므로 Rendezvous 보다 허용 범위가 넓다.
+func TestRingDistribution(t *testing.T) {
+	checkDistribution(t, NewRing(0, nil), map[string]int{"a": 1, "b": 1, "c": 1, "d": 1}, 0.2)
+}
+
+func TestRingWeights(t *testing.T) {
+	checkDistribution(t, NewRing(0, nil), map[string]int{"a": 1, "b": 2, "c": 3}, 0.2)
+}
+
+func TestRingMinimalMovement(t *testing.T) {
+	checkMovement(t, NewRing(0, nil), 5)
+}
+
+func TestRingOrderIndependence(t *testing.T) {
+	a := NewRing(0, nil)
+	a.Add("a", 1)
+	a.Add("b", 2)
+	a.Add("c", 1)
+
+	b := NewRing(0, nil)
+	b.Add("c", 1)
+	b.Add("x", 1)
+	b.Add("b", 2)
+	b.Add("a", 1)
+	b.Remove("x")
+
+	if !reflect.DeepEqual(a.points, b.points) || !reflect.DeepEqual(a.owners, b.owners) {
+		t.Fatal("rings built in different order are not identical")
+	}
+	if !reflect.DeepEqual(assignments(t, a), assignments(t, b)) {
+		t.Fatal("rings built in different order assign keys differently")
+	}
+}
+
+func TestRingGetN(t *testing.T) {
+	r := NewRing(0, nil)
+	if nodes := r.GetN("key", 2); nodes != nil {
+		t.Fatalf("GetN on empty ring = %v, expected nil", nodes)
+	}
+	r.Add("a", 1)
+	r.Add("b", 1)
+	r.Add("c", 1)
+
+	nodes := r.GetN("key", 5)
+	if len(nodes) != 3 {
+		t.Fatalf("GetN returned %v, expected 3 distinct nodes", nodes)
+	}
+	if first, _ := r.Get("key"); nodes[0] != first {
+		t.Fatalf("GetN first node %s, expected Get result %s", nodes[0], first)
+	}
+}
+
+func BenchmarkRingGet(b *testing.B) {
+	for _, nodes := range []int{3, 16, 128} {
+		b.Run(fmt.Sprintf("nodes=%d", nodes), func(b *testing.B) {
+			benchmarkGet(b, NewRing(0, nil), nodes)
+		})
+	}
+}
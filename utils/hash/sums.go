@@ -0,0 +1,324 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package hash
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ===== [ Constants and Variables ] =====
+const (
+	SumOK       SumStatus = "OK"      // Digest 일치
+	SumMismatch SumStatus = "FAILED"  // Digest 불일치
+	SumMissing  SumStatus = "MISSING" // 파일이 존재하지 않음
+	SumError    SumStatus = "ERROR"   // 파일을 읽을 수 없음
+)
+
+var (
+	ErrInvalidSumLine = errors.New("invalid checksum line")           // 체크섬 파일 형식이 올바르지 않은 경우
+	ErrUnsafeSumPath  = errors.New("checksum path escapes directory") // 절대 경로이거나 기준 디렉터리 밖을 가리키는 경로인 경우
+)
+
+// bsdTags - BSD 형식 (tagged) 라인에서 사용하는 알고리즘 태그
+var bsdTags = map[Algorithm]string{
+	MD5:    "MD5",
+	SHA1:   "SHA1",
+	SHA256: "SHA256",
+	SHA384: "SHA384",
+	SHA512: "SHA512",
+	CRC32C: "CRC32C",
+}
+
+// ========== [ SumEntry START ] =========
+
+// SumEntry - 체크섬 파일 (SHA256SUMS, MD5SUMS 등) 의 한 라인 정보 관리용
+type SumEntry struct {
+	Algorithm Algorithm // Hash 알고리즘
+	Path      string    // 파일 경로
+	Digest    []byte    // Digest
+	Binary    bool      // 바이너리 모드 표시 (`*`) 여부
+	Tagged    bool      // BSD 형식 (`SHA256 (path) = hex`) 여부
+}
+
+// String - GNU coreutils 형식의 라인 반환 (개행 제외)
+// conditions:
+// - 경로에 '\' 또는 개행이 포함된 경우는 coreutils 와 동일하게 라인 앞에 '\' 를 붙이고 경로를 escape 한다.
+func (e SumEntry) String() string {
+	name, escaped := escapeSumPath(e.Path)
+	prefix := ""
+	if escaped {
+		prefix = `\`
+	}
+
+	if e.Tagged {
+		return fmt.Sprintf("%s%s (%s) = %s", prefix, bsdTag(e.Algorithm), name, hex.EncodeToString(e.Digest))
+	}
+
+	mode := " "
+	if e.Binary {
+		mode = "*"
+	}
+	return fmt.Sprintf("%s%s %s%s", prefix, hex.EncodeToString(e.Digest), mode, name)
+}
+
+// ========== [ SumEntry END ] =========
+
+// ========== [ SumResult START ] =========
+
+// SumStatus - 체크섬 검증 상태
+type SumStatus string
+
+// SumResult - 체크섬 검증 결과
+type SumResult struct {
+	Entry  SumEntry  // 검증한 라인
+	Status SumStatus // 검증 상태
+	Err    error     // 파일을 읽는 중 발생한 오류 (SumMissing, SumError 인 경우)
+}
+
+// ========== [ SumResult END ] =========
+
+// ===== [ Private Functions ] =====
+
+// bsdTag - 알고리즘의 BSD 태그 반환
+func bsdTag(a Algorithm) string {
+	if tag, ok := bsdTags[a]; ok {
+		return tag
+	}
+	return strings.ToUpper(string(a))
+}
+
+// escapeSumPath - coreutils 규칙에 따라 경로 escape
+func escapeSumPath(p string) (string, bool) {
+	if !strings.ContainsAny(p, "\\\n\r") {
+		return p, false
+	}
+	r := strings.NewReplacer(`\`, `\\`, "\n", `\n`, "\r", `\r`)
+	return r.Replace(p), true
+}
+
+// sumFilePath - 지정한 디렉터리 기준의 파일 경로 반환
+// conditions:
+// - 절대 경로이거나 정리된 경로가 ".." 으로 시작하면 ErrUnsafeSumPath
+func sumFilePath(dir, p string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(p))
+	if strings.HasPrefix(p, "/") || filepath.IsAbs(clean) || filepath.VolumeName(clean) != "" ||
+		clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: %s", ErrUnsafeSumPath, p)
+	}
+	return filepath.Join(dir, clean), nil
+}
+
+// unescapeSumPath - coreutils 규칙으로 escape 된 경로 복원
+func unescapeSumPath(p string) (string, error) {
+	var sb strings.Builder
+	for i := 0; i < len(p); i++ {
+		if p[i] != '\\' {
+			sb.WriteByte(p[i])
+			continue
+		}
+		if i+1 >= len(p) {
+			return "", ErrInvalidSumLine
+		}
+		i++
+		switch p[i] {
+		case '\\':
+			sb.WriteByte('\\')
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		default:
+			return "", ErrInvalidSumLine
+		}
+	}
+	return sb.String(), nil
+}
+
+// algorithmBySize - Digest 길이로 알고리즘 추정
+func algorithmBySize(size int) Algorithm {
+	for _, a := range []Algorithm{MD5, SHA1, SHA256, SHA384, SHA512} {
+		if a.Size() == size {
+			return a
+		}
+	}
+	return ""
+}
+
+// parseSumLine - 체크섬 파일의 한 라인 해석
+func parseSumLine(line string, algorithm Algorithm) (SumEntry, error) {
+	var entry SumEntry
+
+	escaped := strings.HasPrefix(line, `\`)
+	if escaped {
+		line = line[1:]
+	}
+
+	// BSD 형식: `TAG (path) = hex`
+	if idx := strings.Index(line, " ("); idx > 0 && !strings.ContainsAny(line[:idx], " *") {
+		end := strings.LastIndex(line, ") = ")
+		if end < idx {
+			return entry, ErrInvalidSumLine
+		}
+		a, err := ParseAlgorithm(line[:idx])
+		if err != nil {
+			return entry, err
+		}
+		entry.Algorithm, entry.Tagged = a, true
+		entry.Path = line[idx+2 : end]
+		line = line[end+4:]
+		if entry.Digest, err = hex.DecodeString(line); err != nil {
+			return entry, ErrInvalidSumLine
+		}
+	} else {
+		// GNU 형식: `hex  path` 또는 `hex *path`
+		idx := strings.IndexByte(line, ' ')
+		if idx <= 0 || idx+2 > len(line) {
+			return entry, ErrInvalidSumLine
+		}
+		digest, err := hex.DecodeString(line[:idx])
+		if err != nil {
+			return entry, ErrInvalidSumLine
+		}
+		switch line[idx+1] {
+		case '*':
+			entry.Binary = true
+		case ' ':
+		default:
+			return entry, ErrInvalidSumLine
+		}
+		entry.Digest, entry.Path = digest, line[idx+2:]
+
+		entry.Algorithm = algorithm
+		if entry.Algorithm == "" {
+			entry.Algorithm = algorithmBySize(len(digest))
+		}
+	}
+
+	if entry.Path == "" || entry.Algorithm.Size() != len(entry.Digest) {
+		return entry, ErrInvalidSumLine
+	}
+	if escaped {
+		p, err := unescapeSumPath(entry.Path)
+		if err != nil {
+			return entry, err
+		}
+		entry.Path = p
+	}
+	return entry, nil
+}
+
+// hashPath - 지정한 파일의 Digest 를 Hex 문자열로 반환
+func hashPath(p string, algorithm Algorithm) (string, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	return GetHash(f, algorithm)
+}
+
+// ===== [ Public Functions ] =====
+
+// ParseSums - GNU coreutils 체크섬 파일 (sha256sum, md5sum 등의 출력) 해석
+// conditions:
+// - GNU 형식 (`hex  path`, `hex *path`) 과 BSD 형식 (`SHA256 (path) = hex`) 을 모두 지원한다.
+// - GNU 형식은 지정한 알고리즘을 사용하며, 빈 값이면 Digest 길이로 추정한다.
+// - 빈 라인은 무시하며, 형식이 올바르지 않은 라인이 있으면 라인 번호와 함께 오류를 반환한다.
+func ParseSums(reader io.Reader, algorithm Algorithm) ([]SumEntry, error) {
+	var entries []SumEntry
+
+	scanner := bufio.NewScanner(reader)
+	for no := 1; scanner.Scan(); no++ {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		entry, err := parseSumLine(line, algorithm)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", no, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// WriteSums - 지정한 항목들을 GNU coreutils 체크섬 파일 형식으로 출력
+func WriteSums(writer io.Writer, entries []SumEntry) error {
+	w := bufio.NewWriter(writer)
+	for _, entry := range entries {
+		if _, err := w.WriteString(entry.String() + "\n"); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// GenerateSums - 지정한 디렉터리 기준의 파일들에 대한 체크섬 항목 생성
+// conditions:
+// - 경로는 지정한 순서대로 '/' 구분 경로로 기록된다.
+// - 절대 경로이거나 디렉터리 밖을 가리키는 경로는 ErrUnsafeSumPath
+func GenerateSums(dir string, paths []string, algorithm Algorithm, binary, tagged bool) ([]SumEntry, error) {
+	entries := make([]SumEntry, 0, len(paths))
+	for _, p := range paths {
+		filePath, err := sumFilePath(dir, p)
+		if err != nil {
+			return nil, err
+		}
+		sum, err := hashPath(filePath, algorithm)
+		if err != nil {
+			return nil, err
+		}
+		digest, _ := hex.DecodeString(sum)
+		entries = append(entries, SumEntry{
+			Algorithm: algorithm,
+			Path:      filepath.ToSlash(p),
+			Digest:    digest,
+			Binary:    binary,
+			Tagged:    tagged,
+		})
+	}
+	return entries, nil
+}
+
+// VerifySums - 지정한 디렉터리 기준으로 체크섬 항목들을 검증하고 항목별 결과 반환
+// conditions:
+// - 파일이 없으면 SumMissing, 읽을 수 없으면 SumError, Digest 가 다르면 SumMismatch
+// - 절대 경로이거나 디렉터리 밖을 가리키는 경로는 파일을 읽지 않고 ErrUnsafeSumPath 와 함께 SumError
+func VerifySums(dir string, entries []SumEntry) []SumResult {
+	results := make([]SumResult, 0, len(entries))
+	for _, entry := range entries {
+		result := SumResult{Entry: entry, Status: SumOK}
+
+		filePath, err := sumFilePath(dir, entry.Path)
+		if err != nil {
+			result.Status, result.Err = SumError, err
+			results = append(results, result)
+			continue
+		}
+		sum, err := hashPath(filePath, entry.Algorithm)
+		switch {
+		case os.IsNotExist(err):
+			result.Status, result.Err = SumMissing, err
+		case err != nil:
+			result.Status, result.Err = SumError, err
+		case sum != hex.EncodeToString(entry.Digest):
+			result.Status = SumMismatch
+		}
+		results = append(results, result)
+	}
+	return results
+}
@@ -0,0 +1,419 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package hash
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sync"
+)
+
+// ===== [ Constants and Variables ] =====
+const (
+	NodeFile    NodeType = "file"    // 일반 파일
+	NodeDir     NodeType = "dir"     // 디렉터리
+	NodeSymlink NodeType = "symlink" // 따라가지 않은 심볼릭 링크
+	NodeSpecial NodeType = "special" // 소켓, FIFO, 장치 등의 특수 파일 (내용은 읽지 않음)
+
+	ChangeAdded    ChangeType = "added"    // b 에만 존재
+	ChangeRemoved  ChangeType = "removed"  // a 에만 존재
+	ChangeModified ChangeType = "modified" // 양쪽에 존재하지만 Digest 가 다름
+)
+
+var (
+	ErrSymlinkLoop = errors.New("symlink loop detected") // 심볼릭 링크를 따라가다 순환이 발생한 경우
+)
+
+// ========== [ TreeNode START ] =========
+
+// NodeType - Tree 노드 유형
+type NodeType string
+
+// TreeOptions - 디렉터리 Tree Hash 구성 옵션
+type TreeOptions struct {
+	Algorithm      Algorithm // Hash 알고리즘 (빈 값이면 SHA256)
+	IncludeMode    bool      // 권한 비트를 Digest 에 포함할지 여부
+	FollowSymlinks bool      // 심볼릭 링크를 따라갈지 여부 (false 면 링크 대상 경로를 Digest 에 반영)
+	Excludes       []string  // 제외할 Glob 패턴들 (루트 기준 '/' 구분 경로 또는 파일명과 비교)
+	Workers        int       // 파일 내용을 병렬로 Hash 할 작업자 수 (0 이하면 runtime.NumCPU())
+}
+
+// TreeNode - 디렉터리 Tree 의 Merkle 노드 정보 관리용
+// conditions:
+// - 디렉터리 Digest 는 이름순으로 정렬된 자식들의 이름과 Digest 로 계산되므로 하위 변경이 상위로 전파된다.
+type TreeNode struct {
+	Name     string      // 파일명 (루트는 ".")
+	Path     string      // 루트 기준 '/' 구분 경로 (루트는 ".")
+	Type     NodeType    // 노드 유형
+	Mode     os.FileMode // 파일 모드
+	Size     int64       // 파일 크기 (파일인 경우)
+	Target   string      // 링크 대상 경로 (심볼릭 링크인 경우)
+	Digest   []byte      // 노드 Digest
+	Children []*TreeNode // 이름순으로 정렬된 자식 노드들 (디렉터리인 경우)
+
+	content []byte // 파일 내용 Digest
+}
+
+// Hex - 노드 Digest 를 Hex 문자열로 반환
+func (n *TreeNode) Hex() string {
+	return hex.EncodeToString(n.Digest)
+}
+
+// Find - 지정한 루트 기준 '/' 구분 경로의 노드 반환
+// conditions:
+// - 존재하지 않으면 nil 반환
+func (n *TreeNode) Find(p string) *TreeNode {
+	p = path.Clean(filepath.ToSlash(p))
+	if p == "." || p == n.Path {
+		return n
+	}
+
+	node := n
+	for _, name := range splitPath(p) {
+		var next *TreeNode
+		for _, child := range node.Children {
+			if child.Name == name {
+				next = child
+				break
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		node = next
+	}
+	return node
+}
+
+// Walk - 노드와 모든 하위 노드를 깊이 우선으로 순회
+// conditions:
+// - 지정한 함수가 false 를 반환하면 해당 노드의 하위는 순회하지 않는다.
+func (n *TreeNode) Walk(fn func(node *TreeNode) bool) {
+	if !fn(n) {
+		return
+	}
+	for _, child := range n.Children {
+		child.Walk(fn)
+	}
+}
+
+// ========== [ TreeNode END ] =========
+
+// ========== [ TreeChange START ] =========
+
+// ChangeType - Tree 변경 유형
+type ChangeType string
+
+// TreeChange - 두 Tree 간의 변경 정보
+type TreeChange struct {
+	Path string     // 루트 기준 '/' 구분 경로
+	Type ChangeType // 변경 유형
+}
+
+// ========== [ TreeChange END ] =========
+
+// ========== [ treeHasher START ] =========
+
+// treeHasher - Tree 구성 및 Digest 계산 처리용
+type treeHasher struct {
+	opts    TreeOptions
+	files   []*treeFile
+	visited map[string]bool
+}
+
+// treeFile - 내용 Hash 대상 파일
+type treeFile struct {
+	node *TreeNode
+	path string
+}
+
+// build - 지정한 경로의 노드를 구성
+func (t *treeHasher) build(osPath, relPath string, info os.FileInfo) (*TreeNode, error) {
+	node := &TreeNode{
+		Name: path.Base(relPath),
+		Path: relPath,
+		Mode: info.Mode(),
+	}
+
+	// 심볼릭 링크 처리
+	if info.Mode()&os.ModeSymlink != 0 {
+		target, err := os.Readlink(osPath)
+		if err != nil {
+			return nil, err
+		}
+		if !t.opts.FollowSymlinks {
+			node.Type = NodeSymlink
+			node.Target = filepath.ToSlash(target)
+			return node, nil
+		}
+		if info, err = os.Stat(osPath); err != nil {
+			return nil, err
+		}
+		node.Mode = info.Mode()
+	}
+
+	// 특수 파일은 내용을 읽으면 블록될 수 있으므로 유형만 기록
+	if !info.IsDir() && !info.Mode().IsRegular() {
+		node.Type = NodeSpecial
+		return node, nil
+	}
+
+	if info.Mode().IsRegular() {
+		node.Type = NodeFile
+		node.Size = info.Size()
+		t.files = append(t.files, &treeFile{node: node, path: osPath})
+		return node, nil
+	}
+
+	// 디렉터리 순환 검증
+	node.Type = NodeDir
+	real, err := filepath.EvalSymlinks(osPath)
+	if err != nil {
+		return nil, err
+	}
+	if t.visited[real] {
+		return nil, fmt.Errorf("%w: %s", ErrSymlinkLoop, osPath)
+	}
+	t.visited[real] = true
+	defer delete(t.visited, real)
+
+	// os.ReadDir 는 이름순으로 정렬된 결과를 반환한다.
+	entries, err := os.ReadDir(osPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		childRel := entry.Name()
+		if relPath != "." {
+			childRel = relPath + "/" + entry.Name()
+		}
+		if t.excluded(childRel) {
+			continue
+		}
+
+		childInfo, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		child, err := t.build(filepath.Join(osPath, entry.Name()), childRel, childInfo)
+		if err != nil {
+			return nil, err
+		}
+		node.Children = append(node.Children, child)
+	}
+
+	return node, nil
+}
+
+// excluded - 지정한 경로가 제외 패턴과 일치하는지 여부
+func (t *treeHasher) excluded(relPath string) bool {
+	for _, pattern := range t.opts.Excludes {
+		if ok, _ := path.Match(pattern, relPath); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, path.Base(relPath)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// hashFiles - 수집된 파일들의 내용을 병렬로 Hash
+func (t *treeHasher) hashFiles() error {
+	workers := t.opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	jobs := make(chan *treeFile)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			buf := make([]byte, copyBufferSize)
+			for f := range jobs {
+				sum, err := t.hashFile(f.path, buf)
+				if err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					mu.Unlock()
+					continue
+				}
+				f.node.content = sum
+			}
+		}()
+	}
+
+	for _, f := range t.files {
+		jobs <- f
+	}
+	close(jobs)
+	wg.Wait()
+
+	return firstErr
+}
+
+// hashFile - 지정한 파일 내용의 Digest 계산
+func (t *treeHasher) hashFile(osPath string, buf []byte) ([]byte, error) {
+	f, err := os.Open(osPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	h, err := t.opts.Algorithm.New()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.CopyBuffer(h, f, buf); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// digest - 하위 노드부터 노드 Digest 계산
+// conditions:
+// - 각 유형별로 `<type>\x00<mode>\x00<내용>` 형식을 Hash 한다.
+func (t *treeHasher) digest(node *TreeNode) error {
+	h, err := t.opts.Algorithm.New()
+	if err != nil {
+		return err
+	}
+
+	h.Write([]byte(node.Type))
+	h.Write([]byte{0})
+	if t.opts.IncludeMode {
+		fmt.Fprintf(h, "%o", node.Mode.Perm())
+	}
+	h.Write([]byte{0})
+
+	switch node.Type {
+	case NodeFile:
+		h.Write(node.content)
+	case NodeSymlink:
+		h.Write([]byte(node.Target))
+	case NodeSpecial:
+		h.Write([]byte(node.Mode.Type().String()))
+	case NodeDir:
+		for _, child := range node.Children {
+			if err := t.digest(child); err != nil {
+				return err
+			}
+			h.Write([]byte(child.Name))
+			h.Write([]byte{0})
+			h.Write(child.Digest)
+		}
+	}
+
+	node.Digest = h.Sum(nil)
+	node.content = nil
+	return nil
+}
+
+// ========== [ treeHasher END ] =========
+
+// ===== [ Private Functions ] =====
+
+// splitPath - '/' 구분 경로를 이름들로 분리
+func splitPath(p string) []string {
+	var names []string
+	for p != "." && p != "/" && p != "" {
+		dir, name := path.Split(p)
+		names = append([]string{name}, names...)
+		p = path.Clean(dir)
+	}
+	return names
+}
+
+// diffTrees - 두 노드를 재귀적으로 비교해서 변경 정보 수집
+func diffTrees(a, b *TreeNode, changes []TreeChange) []TreeChange {
+	if string(a.Digest) == string(b.Digest) && a.Type == b.Type {
+		return changes
+	}
+	if a.Type != NodeDir || b.Type != NodeDir {
+		return append(changes, TreeChange{Path: b.Path, Type: ChangeModified})
+	}
+
+	before := len(changes)
+	i, j := 0, 0
+	for i < len(a.Children) || j < len(b.Children) {
+		switch {
+		case j >= len(b.Children) || (i < len(a.Children) && a.Children[i].Name < b.Children[j].Name):
+			changes = append(changes, TreeChange{Path: a.Children[i].Path, Type: ChangeRemoved})
+			i++
+		case i >= len(a.Children) || a.Children[i].Name > b.Children[j].Name:
+			changes = append(changes, TreeChange{Path: b.Children[j].Path, Type: ChangeAdded})
+			j++
+		default:
+			changes = diffTrees(a.Children[i], b.Children[j], changes)
+			i++
+			j++
+		}
+	}
+
+	// 자식은 동일하지만 디렉터리 자체 (권한) 가 다른 경우
+	if len(changes) == before {
+		changes = append(changes, TreeChange{Path: b.Path, Type: ChangeModified})
+	}
+	return changes
+}
+
+// ===== [ Public Functions ] =====
+
+// HashTree - 지정한 디렉터리를 순회하며 재현 가능한 Merkle Tree 구성
+// conditions:
+// - 경로는 루트 기준 '/' 구분 경로로 정규화되며, 루트 경로 자체는 Digest 에 포함되지 않는다.
+// - 제외 패턴과 일치하는 디렉터리는 하위 전체를 제외한다.
+// - 파일 내용은 Workers 수만큼 병렬로 Hash 한다.
+// - 소켓, FIFO, 장치 등의 특수 파일은 내용을 읽지 않고 NodeSpecial 유형과 파일 유형만 Digest 에 반영한다.
+func HashTree(root string, opts TreeOptions) (*TreeNode, error) {
+	if opts.Algorithm == "" {
+		opts.Algorithm = SHA256
+	}
+	if !opts.Algorithm.Available() {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, opts.Algorithm)
+	}
+
+	info, err := os.Lstat(root)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &treeHasher{opts: opts, visited: map[string]bool{}}
+	node, err := t.build(root, ".", info)
+	if err != nil {
+		return nil, err
+	}
+	node.Name = "."
+
+	if err := t.hashFiles(); err != nil {
+		return nil, err
+	}
+	if err := t.digest(node); err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// DiffTrees - 두 Tree 간의 변경된 경로들 반환
+// conditions:
+// - Digest 가 같은 하위 Tree 는 비교하지 않는다.
+// - 디렉터리는 하위 변경 경로들로 보고하며, 추가/삭제된 디렉터리는 해당 디렉터리 경로만 보고한다.
+func DiffTrees(a, b *TreeNode) []TreeChange {
+	return diffTrees(a, b, nil)
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package hash
+
+import (
+	"crypto/md5"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+)
+
+// ===== [ Constants and Variables ] =====
+const ()
+
+var (
+	ErrChecksumMismatch = errors.New("checksum mismatch") // 읽은 내용의 Digest 가 기대값과 다른 경우
+)
+
+// ========== [ ChecksumMismatchError START ] =========
+
+// ChecksumMismatchError - Digest 불일치 오류 정보 관리용
+// conditions:
+// - errors.Is(err, ErrChecksumMismatch) 로 판단할 수 있다.
+type ChecksumMismatchError struct {
+	Expected []byte // 기대한 Digest
+	Actual   []byte // 실제 읽은 내용의 Digest
+}
+
+// Error - 오류 메시지 반환
+func (e *ChecksumMismatchError) Error() string {
+	return fmt.Sprintf("%v: expected %x, actual %x", ErrChecksumMismatch, e.Expected, e.Actual)
+}
+
+// Is - ErrChecksumMismatch 와 동일한 오류로 판단
+func (e *ChecksumMismatchError) Is(target error) bool {
+	return target == ErrChecksumMismatch
+}
+
+// ========== [ ChecksumMismatchError END ] =========
+
+// ========== [ VerifyingReader START ] =========
+
+// VerifyingReader - 읽은 내용의 Digest 를 기대값과 비교하는 Reader 정보 관리용
+type VerifyingReader struct {
+	hash     hash.Hash
+	body     io.Reader
+	expected []byte
+	err      error
+}
+
+// Read - 원본 Reader 에서 읽은 내용을 Digest 에 반영하고 크기와 오류를 반환
+// conditions:
+// - 원본 Reader 가 io.EOF 를 반환하는 시점에 Digest 를 비교하고, 다르면 io.EOF 대신 *ChecksumMismatchError 를 반환한다.
+// - 한번 반환된 io.EOF 또는 오류는 이후 호출에서도 계속 반환된다.
+func (r *VerifyingReader) Read(b []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+
+	n, err := r.body.Read(b)
+	if n > 0 {
+		r.hash.Write(b[:n])
+	}
+
+	if err == io.EOF {
+		if actual := r.hash.Sum(nil); subtle.ConstantTimeCompare(actual, r.expected) != 1 {
+			err = &ChecksumMismatchError{Expected: r.expected, Actual: actual}
+		}
+	}
+	if err != nil {
+		r.err = err
+	}
+
+	return n, err
+}
+
+// Sum - 현재까지 읽은 내용의 Digest 반환
+func (r *VerifyingReader) Sum() []byte {
+	return r.hash.Sum(nil)
+}
+
+// ========== [ VerifyingReader END ] =========
+
+// ===== [ Private Functions ] =====
+// ===== [ Public Functions ] =====
+
+// NewVerifyingReader - 지정한 hash.Hash 와 기대 Digest 로 검증하는 Reader 생성
+func NewVerifyingReader(reader io.Reader, h hash.Hash, expected []byte) *VerifyingReader {
+	return &VerifyingReader{
+		hash:     h,
+		body:     reader,
+		expected: expected,
+	}
+}
+
+// NewMD5VerifyingReader - 지정한 MD5 Digest 로 검증하는 Reader 생성
+func NewMD5VerifyingReader(reader io.Reader, expected []byte) *VerifyingReader {
+	return NewVerifyingReader(reader, md5.New(), expected)
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package id
+
+import (
+	"errors"
+	"fmt"
+	gostrings "strings"
+	"time"
+)
+
+// ===== [ Constants and Variables ] =====
+const (
+	maskSequence  = uint64(maxSequence) << BitLenMachineID
+	maskMachineID = uint64(1<<BitLenMachineID - 1)
+)
+
+var (
+	ErrInvalidID = errors.New("invalid id") // Generator 로 생성된 ID 형식이 아닌 경우
+)
+
+// ========== [ Parts START ] =========
+
+// Parts - ID 를 구성하는 정보
+type Parts struct {
+	ID        uint64    // ID
+	Elapsed   uint64    // 기준 시각으로부터의 경과 시간 (TimeUnit 단위)
+	Time      time.Time // 생성 시각 (TimeUnit 단위로 절삭, UTC)
+	Sequence  uint16    // 같은 시간 단위 내의 순번
+	MachineID uint16    // 머신 ID
+}
+
+// ========== [ Parts END ] =========
+
+// Decompose - 지정한 ID 를 Generator 의 기준 시각으로 분해
+func (g *Generator) Decompose(id uint64) Parts {
+	return decompose(id, g.startTime)
+}
+
+// ===== [ Private Functions ] =====
+
+// decompose - 지정한 ID 를 지정한 기준 시각 (TimeUnit 단위) 으로 분해
+func decompose(id uint64, startTime int64) Parts {
+	elapsed := id >> (BitLenSequence + BitLenMachineID)
+	return Parts{
+		ID:        id,
+		Elapsed:   elapsed,
+		Time:      time.Unix(0, (startTime+int64(elapsed))*int64(TimeUnit)).UTC(),
+		Sequence:  uint16((id & maskSequence) >> BitLenMachineID),
+		MachineID: uint16(id & maskMachineID),
+	}
+}
+
+// parse - 접두어를 제거하고 지정한 Encoder 로 ID 복원
+func parse(prefix, s string, e *Encoder) (uint64, error) {
+	if !gostrings.HasPrefix(s, prefix) || len(s) == len(prefix) {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidID, s)
+	}
+	return e.Decode(s[len(prefix):])
+}
+
+// ===== [ Public Functions ] =====
+
+// Decompose - 지정한 ID 를 DefaultStartTime 기준으로 분해
+// conditions:
+// - 다른 기준 시각을 사용하는 Generator 의 ID 는 Generator.Decompose 를 사용해야 한다.
+func Decompose(id uint64) Parts {
+	return decompose(id, toTimeUnit(DefaultStartTime))
+}
+
+// Parse - GetUuid 로 생성된 문자열을 ID 로 복원
+// conditions:
+// - Salt 없는 기본 Encoder 를 사용하므로, Options.Encoder 를 지정한 경우는 ParseWithEncoder 를 사용해야 한다.
+// - 지정한 접두어로 시작하지 않거나, 생성 규칙으로 만들 수 없는 문자열은 ErrInvalidID
+func Parse(prefix, s string) (uint64, error) {
+	return parse(prefix, s, defaultEncoder)
+}
+
+// Parse36 - GetUuid36 으로 생성된 문자열을 ID 로 복원
+// conditions:
+// - Salt 없는 기본 Encoder 를 사용하므로, Options.Encoder36 을 지정한 경우는 ParseWithEncoder 를 사용해야 한다.
+// - 지정한 접두어로 시작하지 않거나, 생성 규칙으로 만들 수 없는 문자열은 ErrInvalidID
+func Parse36(prefix, s string) (uint64, error) {
+	return parse(prefix, s, defaultEncoder36)
+}
+
+// ParseWithEncoder - Generator.NextString 으로 생성된 문자열을 지정한 Encoder 로 ID 복원
+// conditions:
+// - 지정한 접두어로 시작하지 않거나, 생성 규칙으로 만들 수 없는 문자열은 ErrInvalidID
+func ParseWithEncoder(prefix, s string, e *Encoder) (uint64, error) {
+	return parse(prefix, s, e)
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package id
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/ccambo/gocorelib/utils/strings"
+	hashids "github.com/speps/go-hashids"
+)
+
+// ===== [ Constants and Variables ] =====
+const ()
+
+var (
+	ErrValueOutOfRange = errors.New("value out of range for hashids") // hashids 로 인코딩할 수 없는 값인 경우 (MaxInt64 초과)
+)
+
+var (
+	defaultEncoder   = mustNewEncoder(EncoderOptions{})                     // GetUuid 에서 사용하는 Encoder
+	defaultEncoder36 = mustNewEncoder(EncoderOptions{Alphabet: Alphabet36}) // GetUuid36 에서 사용하는 Encoder
+)
+
+// ========== [ Encoder START ] =========
+
+// EncoderOptions - hashids Encoder 구성 옵션
+type EncoderOptions struct {
+	Salt      string // Salt (빈 값이면 Salt 없이 인코딩하므로 누구나 복원할 수 있다)
+	MinLength int    // 인코딩 결과의 최소 길이
+	Alphabet  string // 사용할 문자들 (빈 값이면 hashids 기본 Alphabet)
+}
+
+// Encoder - uint64 ID 를 hashids 문자열로 변환하기 위한 정보 관리용
+// conditions:
+// - 생성 후에는 변경되지 않으므로 동시 사용에 안전하다.
+// - GetUuid 형식과 호환되도록 hashids 결과를 뒤집은 문자열을 사용한다.
+type Encoder struct {
+	h *hashids.HashID
+}
+
+// Encode - 지정한 ID 를 문자열로 변환
+// conditions:
+// - hashids 는 int64 범위만 지원하므로 MaxInt64 를 초과하면 ErrValueOutOfRange
+func (e *Encoder) Encode(id uint64) (string, error) {
+	if id > math.MaxInt64 {
+		return "", fmt.Errorf("%w: %d", ErrValueOutOfRange, id)
+	}
+	s, err := e.h.EncodeInt64([]int64{int64(id)})
+	if err != nil {
+		return "", err
+	}
+	return strings.Reverse(s), nil
+}
+
+// Decode - Encode 로 변환된 문자열을 ID 로 복원
+// conditions:
+// - 하나의 0 이상 값으로 복원되고, 다시 인코딩한 결과가 같은 경우만 성공하며 그 외는 ErrInvalidID
+func (e *Encoder) Decode(s string) (uint64, error) {
+	if s == "" {
+		return 0, fmt.Errorf("%w: empty", ErrInvalidID)
+	}
+	numbers, err := e.h.DecodeInt64WithError(strings.Reverse(s))
+	if err != nil || len(numbers) != 1 || numbers[0] < 0 {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidID, s)
+	}
+	return uint64(numbers[0]), nil
+}
+
+// ========== [ Encoder END ] =========
+
+// ===== [ Private Functions ] =====
+
+// mustNewEncoder - 지정한 옵션으로 Encoder 생성, 실패하면 Panic 처리
+func mustNewEncoder(opts EncoderOptions) *Encoder {
+	e, err := NewEncoder(opts)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+// ===== [ Public Functions ] =====
+
+// NewEncoder - 지정한 옵션으로 Encoder 생성
+// conditions:
+// - Alphabet 은 중복 없는 16 자 이상이어야 하며, 공백을 포함할 수 없다.
+func NewEncoder(opts EncoderOptions) (*Encoder, error) {
+	hd := hashids.NewData()
+	hd.Salt = opts.Salt
+	hd.MinLength = opts.MinLength
+	if opts.Alphabet != "" {
+		hd.Alphabet = opts.Alphabet
+	}
+
+	h, err := hashids.NewWithData(hd)
+	if err != nil {
+		return nil, err
+	}
+	return &Encoder{h: h}, nil
+}
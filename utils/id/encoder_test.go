@@ -0,0 +1,78 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package id
+
+import (
+	"testing"
+
+	"github.com/ccambo/gocorelib/utils/strings"
+	hashids "github.com/speps/go-hashids"
+)
+
+// ===== [ Constants and Variables ] =====
+const (
+	benchID = uint64(0x1f2e3d4c5b6a7988) & (1<<63 - 1) // 벤치마크용 ID
+)
+
+var ()
+
+// ===== [ Public Functions ] =====
+
+func TestGeneratorEncoder(t *testing.T) {
+	salted, err := NewEncoder(EncoderOptions{Salt: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := NewGenerator(Options{MachineID: StaticMachineID(1), Encoder: salted})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := g.NextUuid("p-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := ParseWithEncoder("p-", s, g.Encoder())
+	if err != nil {
+		t.Fatal(err)
+	}
+	unsalted, err := defaultEncoder.Encode(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s == "p-"+unsalted {
+		t.Fatalf("NextUuid with salted encoder returned unsalted value %s", s)
+	}
+	if g.Encoder36() != defaultEncoder36 {
+		t.Fatal("Encoder36 should default to the unsalted encoder")
+	}
+}
+
+func BenchmarkEncoderEncode(b *testing.B) {
+	e := mustNewEncoder(EncoderOptions{})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := e.Encode(benchID); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkEncoderEncodeUncached - Encoder 를 재사용하지 않고 호출마다 hashids 를 생성하는 경우 (비교용)
+func BenchmarkEncoderEncodeUncached(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		h, err := hashids.NewWithData(hashids.NewData())
+		if err != nil {
+			b.Fatal(err)
+		}
+		s, err := h.EncodeInt64([]int64{int64(benchID)})
+		if err != nil {
+			b.Fatal(err)
+		}
+		_ = strings.Reverse(s)
+	}
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package id
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ===== [ Constants and Variables ] =====
+const (
+	BitLenTime      = 39                               // 시간 정보 Bit 수
+	BitLenSequence  = 8                                // 순번 정보 Bit 수
+	BitLenMachineID = 63 - BitLenTime - BitLenSequence // 머신 ID 정보 Bit 수
+
+	TimeUnit = 10 * time.Millisecond // 시간 정보 단위
+
+	maxSequence = 1<<BitLenSequence - 1
+)
+
+var (
+	ErrStartTimeAhead   = errors.New("start time is ahead of now")  // 기준 시각이 현재 시각 이후인 경우
+	ErrOverTimeLimit    = errors.New("over the time limit")         // 시간 정보가 BitLenTime 을 초과한 경우
+	ErrInvalidMachineID = errors.New("invalid machine id")          // 머신 ID 검증에 실패한 경우
+	ErrNoMachineID      = errors.New("machine id is not available") // 머신 ID 를 구할 수 없는 경우
+)
+
+// DefaultStartTime - 기본 기준 시각 (sonyflake 와 동일)
+var DefaultStartTime = time.Date(2014, 9, 1, 0, 0, 0, 0, time.UTC)
+
+var (
+	defaultGenerator *Generator
+	defaultMu        sync.RWMutex
+)
+
+// ========== [ Generator START ] =========
+
+// MachineIDFunc - 머신 ID 를 반환하는 함수
+type MachineIDFunc func() (uint16, error)
+
+// Options - Generator 구성 옵션
+type Options struct {
+	MachineID      MachineIDFunc       // 머신 ID (nil 이면 DefaultMachineID)
+	CheckMachineID func(uint16) bool   // 머신 ID 검증 함수 (nil 이면 검증하지 않음)
+	Registry       MachineIDRegistry   // 머신 ID 중복 검증용 Registry (nil 이면 검증하지 않음)
+	Guard          func() error        // ID 생성 전 호출되는 검증 함수, 오류를 반환하면 ID 를 생성하지 않음 (nil 이면 검증하지 않음)
+	StartTime      time.Time           // 기준 시각 (Zero 면 DefaultStartTime)
+	Clock          func() time.Time    // 현재 시각 (nil 이면 time.Now)
+	Sleep          func(time.Duration) // 순번이 소진된 경우의 대기 함수 (nil 이면 time.Sleep)
+	Encoder        *Encoder            // NextUuid (GetUuid) 에서 사용할 Encoder (nil 이면 Salt 없는 기본 Encoder)
+	Encoder36      *Encoder            // NextUuid36 (GetUuid36) 에서 사용할 Encoder (nil 이면 Salt 없는 기본 소문자 Encoder)
+}
+
+// Generator - sonyflake 와 같은 Bit 구성 (시간 39, 순번 8, 머신 ID 16) 의 uint64 ID 생성 정보 관리용
+// conditions:
+// - 동시 사용에 안전하다.
+// - 시각이 되돌아간 경우는 마지막 시각 기준으로 순번을 증가시켜 ID 가 감소하지 않도록 한다.
+type Generator struct {
+	mu          sync.Mutex
+	startTime   int64 // 기준 시각 (TimeUnit 단위)
+	elapsedTime int64 // 마지막 ID 의 경과 시간 (TimeUnit 단위)
+	sequence    uint16
+	machineID   uint16
+	clock       func() time.Time
+	sleep       func(time.Duration)
+	guard       func() error
+	encoder     *Encoder
+	encoder36   *Encoder
+}
+
+// NextID - 다음 ID 생성
+// conditions:
+// - 같은 시간 단위 내의 순번이 모두 소진되면 다음 시간 단위까지 대기한다.
+// - 기준 시각으로부터 약 174 년이 지나면 ErrOverTimeLimit
+// - Guard 가 오류를 반환하면 해당 오류 반환
+func (g *Generator) NextID() (uint64, error) {
+	if g.guard != nil {
+		if err := g.guard(); err != nil {
+			return 0, err
+		}
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	current := toTimeUnit(g.clock()) - g.startTime
+	if g.elapsedTime < current {
+		g.elapsedTime = current
+		g.sequence = 0
+	} else {
+		g.sequence = (g.sequence + 1) & maxSequence
+		if g.sequence == 0 {
+			g.elapsedTime++
+			g.sleep(time.Duration(g.elapsedTime-current) * TimeUnit)
+		}
+	}
+
+	if g.elapsedTime >= 1<<BitLenTime {
+		return 0, ErrOverTimeLimit
+	}
+	return uint64(g.elapsedTime)<<(BitLenSequence+BitLenMachineID) |
+		uint64(g.sequence)<<BitLenMachineID |
+		uint64(g.machineID), nil
+}
+
+// NextUuid - 다음 ID 를 지정한 접두어를 포함한 문자열 형식으로 생성
+// conditions:
+// - 반환 형식은 `B6BZVN3mOPvx...`
+func (g *Generator) NextUuid(prefix string) (string, error) {
+	return g.NextString(prefix, g.encoder)
+}
+
+// NextUuid36 - 다음 ID 를 지정한 접두어를 포함한 소문자 기준의 문자열 형식으로 생성
+// conditions:
+// - 반환 형식은 `300m50zn91nwz5...`
+func (g *Generator) NextUuid36(prefix string) (string, error) {
+	return g.NextString(prefix, g.encoder36)
+}
+
+// MachineID - 사용 중인 머신 ID 반환
+func (g *Generator) MachineID() uint16 {
+	return g.machineID
+}
+
+// Encoder - NextUuid 에서 사용하는 Encoder 반환 (ParseWithEncoder 로 복원할 때 사용)
+func (g *Generator) Encoder() *Encoder {
+	return g.encoder
+}
+
+// Encoder36 - NextUuid36 에서 사용하는 Encoder 반환 (ParseWithEncoder 로 복원할 때 사용)
+func (g *Generator) Encoder36() *Encoder {
+	return g.encoder36
+}
+
+// StartTime - 기준 시각 반환
+func (g *Generator) StartTime() time.Time {
+	return time.Unix(0, g.startTime*int64(TimeUnit)).UTC()
+}
+
+// NextString - 다음 ID 를 지정한 Encoder 로 변환하고 접두어를 포함한 문자열로 생성
+func (g *Generator) NextString(prefix string, e *Encoder) (string, error) {
+	id, err := g.NextID()
+	if err != nil {
+		return "", err
+	}
+	s, err := e.Encode(id)
+	if err != nil {
+		return "", err
+	}
+	return prefix + s, nil
+}
+
+// ========== [ Generator END ] =========
+
+// ===== [ Private Functions ] =====
+
+// toTimeUnit - 지정한 시각을 TimeUnit 단위로 변환
+func toTimeUnit(t time.Time) int64 {
+	return t.UTC().UnixNano() / int64(TimeUnit)
+}
+
+// ===== [ Public Functions ] =====
+
+// NewGenerator - 지정한 옵션으로 Generator 생성
+func NewGenerator(opts Options) (*Generator, error) {
+	if opts.StartTime.IsZero() {
+		opts.StartTime = DefaultStartTime
+	}
+	if opts.Clock == nil {
+		opts.Clock = time.Now
+	}
+	if opts.Sleep == nil {
+		opts.Sleep = time.Sleep
+	}
+	if opts.MachineID == nil {
+		opts.MachineID = DefaultMachineID()
+	}
+	if opts.Encoder == nil {
+		opts.Encoder = defaultEncoder
+	}
+	if opts.Encoder36 == nil {
+		opts.Encoder36 = defaultEncoder36
+	}
+	if opts.StartTime.After(opts.Clock()) {
+		return nil, ErrStartTimeAhead
+	}
+
+	machineID, err := opts.MachineID()
+	if err != nil {
+		return nil, err
+	}
+	if opts.CheckMachineID != nil && !opts.CheckMachineID(machineID) {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidMachineID, machineID)
+	}
+	if opts.Registry != nil {
+		if err := opts.Registry.Register(machineID); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Generator{
+		startTime: toTimeUnit(opts.StartTime),
+		sequence:  maxSequence,
+		machineID: machineID,
+		clock:     opts.Clock,
+		sleep:     opts.Sleep,
+		guard:     opts.Guard,
+		encoder:   opts.Encoder,
+		encoder36: opts.Encoder36,
+	}, nil
+}
+
+// Default - 패키지 함수들이 사용하는 기본 Generator 반환
+// conditions:
+// - SetDefault 로 지정하지 않은 경우는 처음 호출될 때 기본 옵션으로 생성한다.
+// - 생성에 실패하면 저장하지 않고 오류를 반환하며, 다음 호출에서 다시 생성한다. (ex. 시작 직후 네트워크 주소가 없는 경우)
+func Default() (*Generator, error) {
+	defaultMu.RLock()
+	g := defaultGenerator
+	defaultMu.RUnlock()
+	if g != nil {
+		return g, nil
+	}
+
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+
+	if defaultGenerator != nil {
+		return defaultGenerator, nil
+	}
+	g, err := NewGenerator(Options{})
+	if err != nil {
+		return nil, err
+	}
+	defaultGenerator = g
+	return g, nil
+}
+
+// SetDefault - 패키지 함수들이 사용할 기본 Generator 지정
+func SetDefault(g *Generator) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultGenerator = g
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package id
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// ===== [ Types ] =====
+type (
+	// fakeClock - 테스트용 시각
+	fakeClock struct {
+		now time.Time
+	}
+)
+
+// ===== [ Implementations ] =====
+
+// Now - 현재 시각 반환
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+// Sleep - 대기 없이 시각만 이동
+func (c *fakeClock) Sleep(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+// ===== [ Private Functions ] =====
+
+// newTestGenerator - 지정한 시각과 머신 ID 의 테스트용 Generator 생성
+func newTestGenerator(t *testing.T, clock *fakeClock, machineID uint16) *Generator {
+	g, err := NewGenerator(Options{MachineID: StaticMachineID(machineID), Clock: clock.Now, Sleep: clock.Sleep})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+// ===== [ Public Functions ] =====
+
+func TestGeneratorClock(t *testing.T) {
+	clock := &fakeClock{now: DefaultStartTime.Add(time.Second)}
+	g := newTestGenerator(t, clock, 7)
+
+	steps := []struct {
+		advance  time.Duration
+		elapsed  uint64
+		sequence uint16
+	}{
+		{0, 100, 0},
+		{0, 100, 1},
+		{5 * time.Millisecond, 100, 2},
+		{5 * time.Millisecond, 101, 0},
+		{-time.Second, 101, 1}, // 시각이 되돌아가도 마지막 시각 기준으로 증가
+		{2 * time.Second, 201, 0},
+	}
+	var last uint64
+	for i, step := range steps {
+		clock.now = clock.now.Add(step.advance)
+		id, err := g.NextID()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id <= last {
+			t.Fatalf("step %d: id %d is not greater than previous %d", i, id, last)
+		}
+		last = id
+
+		parts := g.Decompose(id)
+		if parts.Elapsed != step.elapsed || parts.Sequence != step.sequence || parts.MachineID != 7 {
+			t.Errorf("step %d: Decompose = %+v, expected elapsed %d, sequence %d, machine id 7", i, parts, step.elapsed, step.sequence)
+		}
+		if expected := DefaultStartTime.Add(time.Duration(step.elapsed) * TimeUnit); !parts.Time.Equal(expected) {
+			t.Errorf("step %d: Decompose time %v, expected %v", i, parts.Time, expected)
+		}
+		if Decompose(id) != parts {
+			t.Errorf("step %d: package Decompose differs from Generator.Decompose", i)
+		}
+	}
+}
+
+func TestGeneratorSequenceOverflow(t *testing.T) {
+	clock := &fakeClock{now: DefaultStartTime.Add(time.Second)}
+	g := newTestGenerator(t, clock, 1)
+
+	var last uint64
+	for i := 0; i <= maxSequence+1; i++ {
+		id, err := g.NextID()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id <= last {
+			t.Fatalf("id %d is not greater than previous %d", id, last)
+		}
+		last = id
+	}
+
+	// 순번이 소진되면 다음 시간 단위까지 대기
+	parts := g.Decompose(last)
+	if parts.Elapsed != 101 || parts.Sequence != 0 {
+		t.Fatalf("id after sequence overflow = %+v, expected elapsed 101, sequence 0", parts)
+	}
+	if expected := DefaultStartTime.Add(time.Second + TimeUnit); !clock.now.Equal(expected) {
+		t.Fatalf("clock after sleep = %v, expected %v", clock.now, expected)
+	}
+}
+
+func TestNewGeneratorErrors(t *testing.T) {
+	clock := &fakeClock{now: DefaultStartTime.Add(time.Hour)}
+	providerErr := errors.New("no address")
+	registry := NewMemoryRegistry()
+	if err := registry.Register(3); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		opts Options
+		err  error
+	}{
+		"provider": {
+			opts: Options{MachineID: func() (uint16, error) { return 0, providerErr }},
+			err:  providerErr,
+		},
+		"check": {
+			opts: Options{MachineID: StaticMachineID(3), CheckMachineID: func(id uint16) bool { return id != 3 }},
+			err:  ErrInvalidMachineID,
+		},
+		"registry": {
+			opts: Options{MachineID: StaticMachineID(3), Registry: registry},
+			err:  ErrDuplicateMachineID,
+		},
+		"start time": {
+			opts: Options{MachineID: StaticMachineID(3), StartTime: clock.now.Add(time.Second)},
+			err:  ErrStartTimeAhead,
+		},
+	} {
+		tc.opts.Clock = clock.Now
+		if _, err := NewGenerator(tc.opts); !errors.Is(err, tc.err) {
+			t.Errorf("%s: NewGenerator returned %v, expected %v", name, err, tc.err)
+		}
+	}
+}
+
+func TestGeneratorGuard(t *testing.T) {
+	guardErr := errors.New("lease lost")
+	g, err := NewGenerator(Options{MachineID: StaticMachineID(1), Guard: func() error { return guardErr }})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.NextID(); !errors.Is(err, guardErr) {
+		t.Fatalf("NextID returned %v, expected guard error", err)
+	}
+	if _, err := g.NextUuid("p-"); !errors.Is(err, guardErr) {
+		t.Fatalf("NextUuid returned %v, expected guard error", err)
+	}
+}
+
+func TestDefaultRetriesAfterError(t *testing.T) {
+	defaultMu.Lock()
+	saved := defaultGenerator
+	defaultGenerator = nil
+	defaultMu.Unlock()
+	t.Cleanup(func() { SetDefault(saved) })
+
+	setEnv(t, DefaultMachineIDEnv, "invalid")
+	if _, err := Default(); !errors.Is(err, ErrInvalidMachineID) {
+		t.Fatalf("Default returned %v, expected ErrInvalidMachineID", err)
+	}
+
+	setEnv(t, DefaultMachineIDEnv, "42")
+	g, err := Default()
+	if err != nil {
+		t.Fatalf("Default did not retry after error: %v", err)
+	}
+	if g.MachineID() != 42 {
+		t.Fatalf("Default machine id = %d, expected 42", g.MachineID())
+	}
+	if again, _ := Default(); again != g {
+		t.Fatal("Default did not keep the generator created on success")
+	}
+}
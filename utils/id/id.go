@@ -6,61 +6,31 @@ package id
 import (
 	"errors"
 	"net"
-
-	"github.com/ccambo/gocorelib/utils/strings"
-	"github.com/sony/sonyflake"
-	hashids "github.com/speps/go-hashids"
 )
 
 // ===== [ Constants and Variables ] =====
 const Alphabet36 = "abcdefghijklmnopqrstuvwxyz1234567890"
 
-var sf *sonyflake.Sonyflake
-var upperMachineID uint16
-
 // ===== [ Private Functions ] =====
 
-// init - Called on package load
-func init() {
-	var st sonyflake.Settings
-
-	// Unique ID Generator 생성
-	sf = sonyflake.NewSonyflake(st)
-	if sf == nil {
-		sf = sonyflake.NewSonyflake(sonyflake.Settings{
-			MachineID: lower16BitIP,
-		})
-		upperMachineID, _ = upper16BitIP()
-	}
-}
-
-// lower16BitIP - IP4 주소의 하위 16비트 반환
-func lower16BitIP() (uint16, error) {
-	ip, err := IPv4()
-	if err != nil {
-		return 0, err
-	}
-
-	return uint16(ip[2])<<8 + uint16(ip[3]), nil
-}
-
-// upper16BitIP - IP4 주소의 상위 16비트 반환
-func upper16BitIP() (uint16, error) {
-	ip, err := IPv4()
-	if err != nil {
-		return 0, err
-	}
-
-	return uint16(ip[0])<<8 + uint16(ip[1]), nil
+// isPrivateIPv4 - 사설 IP4 주소 (10/8, 172.16/12, 192.168/16) 여부
+func isPrivateIPv4(ip net.IP) bool {
+	return ip != nil &&
+		(ip[0] == 10 || ip[0] == 172 && (ip[1] >= 16 && ip[1] < 32) || ip[0] == 192 && ip[1] == 168)
 }
 
 // ===== [ Public Functions ] =====
 
 // GetIntId - Int (uint64) 형식의 UID 생성
 // conditions:
+// - 기본 Generator 를 사용한다.
 // - 생성할 떄 오류 발생시는 Panic 처리
 func GetIntId() uint64 {
-	id, err := sf.NextID()
+	g, err := Default()
+	if err != nil {
+		panic(err)
+	}
+	id, err := g.NextID()
 	if err != nil {
 		panic(err)
 	}
@@ -70,40 +40,35 @@ func GetIntId() uint64 {
 // GetUuid - 지정한 접두어를 포함한 문자열 형식의 UUID 생성
 // conditions:
 // - 반환 형식은 `B6BZVN3mOPvx...`
+// - 기본 Generator 를 사용한다. Salt 를 적용하려면 Options.Encoder 를 지정한 Generator 를 SetDefault 로 지정한다.
 // - 생성할 떄 오류 발생시는 Panic 처리
 func GetUuid(prefix string) string {
-	id := GetIntId()
-	hd := hashids.NewData()
-	h, err := hashids.NewWithData(hd)
+	g, err := Default()
 	if err != nil {
 		panic(err)
 	}
-	i, err := h.Encode([]int{int(id)})
+	s, err := g.NextUuid(prefix)
 	if err != nil {
 		panic(err)
 	}
-
-	return prefix + strings.Reverse(i)
+	return s
 }
 
 // GetUuid36 - 지정한 접두어를 포함한 소문자 기준의 문자열 형식의 UUID 생성
 // conditions:
 // - 반환 형식은 `300m50zn91nwz5...`
+// - 기본 Generator 를 사용한다. Salt 를 적용하려면 Options.Encoder36 를 지정한 Generator 를 SetDefault 로 지정한다.
 // - 생성할 떄 오류 발생시는 Panic 처리
 func GetUuid36(prefix string) string {
-	id := GetIntId()
-	hd := hashids.NewData()
-	hd.Alphabet = Alphabet36
-	h, err := hashids.NewWithData(hd)
+	g, err := Default()
 	if err != nil {
 		panic(err)
 	}
-	i, err := h.Encode([]int{int(id)})
+	s, err := g.NextUuid36(prefix)
 	if err != nil {
 		panic(err)
 	}
-
-	return prefix + strings.Reverse(i)
+	return s
 }
 
 // IPv4 - IP 정보 반환
@@ -129,3 +94,43 @@ func IPv4() (net.IP, error) {
 	}
 	return nil, errors.New("no ip address")
 }
+
+// PrivateIPv4 - 사설 IP 정보 반환
+func PrivateIPv4() (net.IP, error) {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range addrs {
+		ipnet, ok := a.(*net.IPNet)
+		if !ok || ipnet.IP.IsLoopback() {
+			continue
+		}
+
+		ip := ipnet.IP.To4()
+		if isPrivateIPv4(ip) {
+			return ip, nil
+		}
+	}
+	return nil, errors.New("no private ip address")
+}
+
+// IPv6 - 전역 Unicast IPv6 정보 반환
+// conditions:
+// - Loopback, Link-Local 주소와 IPv4 주소는 제외한다.
+func IPv6() (net.IP, error) {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range addrs {
+		ipnet, ok := a.(*net.IPNet)
+		if !ok || ipnet.IP.To4() != nil || !ipnet.IP.IsGlobalUnicast() {
+			continue
+		}
+		return ipnet.IP.To16(), nil
+	}
+	return nil, errors.New("no ipv6 address")
+}
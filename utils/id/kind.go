@@ -0,0 +1,353 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package id
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	gostrings "strings"
+	"sync"
+)
+
+// ===== [ Constants and Variables ] =====
+const (
+	base62Alphabet   = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz" // PublicID 본문과 Check 문자에서 사용하는 문자
+	publicIDBodySize = 11                                                               // uint64 를 Base62 로 표현하는 고정 길이
+	kindSeparator    = "_"                                                              // 접두어와 본문 구분자
+	minPrefixLength  = 2
+	maxPrefixLength  = 8
+)
+
+var (
+	ErrInvalidKind     = errors.New("invalid kind")      // Kind 이름이나 접두어가 올바르지 않은 경우
+	ErrDuplicateKind   = errors.New("duplicate kind")    // 이미 등록된 이름이나 접두어인 경우
+	ErrUnknownKind     = errors.New("unknown kind")      // 등록되지 않은 접두어인 경우
+	ErrInvalidPublicID = errors.New("invalid public id") // PublicID 형식이나 Check 문자가 올바르지 않은 경우
+)
+
+var (
+	kindsMu  sync.RWMutex
+	kinds    = map[string]Kind{} // 접두어별 Kind
+	kindName = map[string]bool{} // 등록된 이름
+)
+
+// base62Values - Base62 문자별 값 (올바르지 않은 문자는 0xff)
+var base62Values = func() [256]byte {
+	var values [256]byte
+	for i := range values {
+		values[i] = 0xff
+	}
+	for i := 0; i < len(base62Alphabet); i++ {
+		values[base62Alphabet[i]] = byte(i)
+	}
+	return values
+}()
+
+// ========== [ Kind START ] =========
+
+// Kind - 접두어로 구분되는 PublicID 의 엔티티 유형 (ex. `usr`, `org`)
+// conditions:
+// - RegisterKind 로 등록한 Kind 만 올바른 값이며, 직접 구성한 Kind (Zero 값 포함) 는 사용하지 않는다.
+type Kind struct {
+	Name   string // 유형 이름 (ex. user)
+	Prefix string // 접두어 (ex. usr), 문자열에는 `usr_` 형식으로 사용
+}
+
+// New - 기본 Generator 로 다음 ID 를 생성해서 PublicID 생성
+func (k Kind) New() (PublicID, error) {
+	g, err := Default()
+	if err != nil {
+		return PublicID{}, err
+	}
+	return k.NewWith(g)
+}
+
+// NewWith - 지정한 Generator 로 다음 ID 를 생성해서 PublicID 생성
+// conditions:
+// - 접두어가 올바르지 않은 Kind (Zero 값 포함) 는 ID 를 생성하지 않고 ErrInvalidKind
+func (k Kind) NewWith(g *Generator) (PublicID, error) {
+	if !validPrefix(k.Prefix) {
+		return PublicID{}, fmt.Errorf("%w: prefix %q", ErrInvalidKind, k.Prefix)
+	}
+	id, err := g.NextID()
+	if err != nil {
+		return PublicID{}, err
+	}
+	return k.FromID(id), nil
+}
+
+// FromID - 지정한 ID 로 PublicID 생성
+// conditions:
+// - 접두어를 검증하지 않으므로 등록된 Kind 에서만 사용한다. (Zero 값 Kind 는 `_<body><check>` 처럼 해석할 수 없는 문자열이 된다)
+func (k Kind) FromID(id uint64) PublicID {
+	return PublicID{kind: k, id: id}
+}
+
+// Parse - 지정한 문자열을 이 Kind 의 PublicID 로 변환
+// conditions:
+// - 접두어가 다르거나, 형식이 올바르지 않거나, Check 문자가 맞지 않으면 ErrInvalidPublicID
+func (k Kind) Parse(s string) (PublicID, error) {
+	prefix, body, ok := splitPublicID(s)
+	if !ok {
+		return PublicID{}, fmt.Errorf("%w: malformed: %q", ErrInvalidPublicID, s)
+	}
+	if prefix != k.Prefix {
+		return PublicID{}, fmt.Errorf("%w: expected prefix %q: %q", ErrInvalidPublicID, k.Prefix, s)
+	}
+	id, err := decodePublicBody(prefix, body)
+	if err != nil {
+		return PublicID{}, fmt.Errorf("%w: %q", err, s)
+	}
+	return PublicID{kind: k, id: id}, nil
+}
+
+// String - 접두어 반환 (ex. `usr_`)
+func (k Kind) String() string {
+	return k.Prefix + kindSeparator
+}
+
+// ========== [ Kind END ] =========
+
+// ========== [ PublicID START ] =========
+
+// PublicID - 접두어와 Check 문자를 포함한 외부 공개용 ID (ex. `usr_0LkGdB2Ks1qX`)
+// conditions:
+// - 형식은 `<prefix>_<11 자리 Base62 ID><Check 문자>` 이며, 같은 Kind 내에서는 문자열 순서와 ID 순서가 같다.
+// - Check 문자는 접두어와 본문에 대한 Luhn mod 62 값이다.
+// - Zero 값은 JSON null, SQL NULL 로 변환된다.
+type PublicID struct {
+	kind Kind
+	id   uint64
+}
+
+// Kind - PublicID 의 Kind 반환
+func (p PublicID) Kind() Kind {
+	return p.kind
+}
+
+// ID - PublicID 의 내부 ID 반환
+func (p PublicID) ID() uint64 {
+	return p.id
+}
+
+// IsZero - 비어있는 PublicID 여부
+func (p PublicID) IsZero() bool {
+	return p.kind.Prefix == "" && p.id == 0
+}
+
+// String - `<prefix>_<body><check>` 형식의 문자열 반환
+func (p PublicID) String() string {
+	if p.IsZero() {
+		return ""
+	}
+	body := encodeBase62(p.id)
+	return p.kind.Prefix + kindSeparator + body + string(luhnCheck(p.kind.Prefix+body))
+}
+
+// MarshalText - 문자열로 변환
+func (p PublicID) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText - 등록된 Kind 기준으로 문자열을 PublicID 로 변환
+func (p *PublicID) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*p = PublicID{}
+		return nil
+	}
+	parsed, err := ParsePublicID(string(text))
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
+
+// MarshalJSON - JSON 문자열로 변환 (Zero 값은 null)
+func (p PublicID) MarshalJSON() ([]byte, error) {
+	if p.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(p.String())
+}
+
+// UnmarshalJSON - JSON 문자열 또는 null 을 PublicID 로 변환
+func (p *PublicID) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*p = PublicID{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPublicID, err)
+	}
+	return p.UnmarshalText([]byte(s))
+}
+
+// Scan - sql.Scanner 구현, 문자열/바이트 배열/NULL 지원
+func (p *PublicID) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*p = PublicID{}
+		return nil
+	case string:
+		return p.UnmarshalText([]byte(v))
+	case []byte:
+		return p.UnmarshalText(v)
+	}
+	return fmt.Errorf("%w: cannot scan %T", ErrInvalidPublicID, src)
+}
+
+// Value - driver.Valuer 구현, Zero 값은 NULL
+func (p PublicID) Value() (driver.Value, error) {
+	if p.IsZero() {
+		return nil, nil
+	}
+	return p.String(), nil
+}
+
+// ========== [ PublicID END ] =========
+
+// ===== [ Private Functions ] =====
+
+// validPrefix - 소문자 영문 2 ~ 8 자리 접두어 여부
+func validPrefix(prefix string) bool {
+	if len(prefix) < minPrefixLength || len(prefix) > maxPrefixLength {
+		return false
+	}
+	for i := 0; i < len(prefix); i++ {
+		if prefix[i] < 'a' || prefix[i] > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// splitPublicID - 문자열을 접두어와 본문 (Check 문자 포함) 으로 분리
+func splitPublicID(s string) (string, string, bool) {
+	idx := gostrings.LastIndex(s, kindSeparator)
+	if idx <= 0 || len(s)-idx-1 != publicIDBodySize+1 {
+		return "", "", false
+	}
+	return s[:idx], s[idx+1:], true
+}
+
+// encodeBase62 - uint64 를 고정 길이 Base62 문자열로 변환
+func encodeBase62(v uint64) string {
+	var buf [publicIDBodySize]byte
+	for i := len(buf) - 1; i >= 0; i-- {
+		buf[i] = base62Alphabet[v%62]
+		v /= 62
+	}
+	return string(buf[:])
+}
+
+// decodeBase62 - 고정 길이 Base62 문자열을 uint64 로 변환, uint64 범위를 넘으면 false
+func decodeBase62(s string) (uint64, bool) {
+	var v uint64
+	for i := 0; i < len(s); i++ {
+		d := base62Values[s[i]]
+		if d == 0xff || v > (^uint64(0)-uint64(d))/62 {
+			return 0, false
+		}
+		v = v*62 + uint64(d)
+	}
+	return v, true
+}
+
+// luhnCheck - 지정한 문자열의 Luhn mod 62 Check 문자 계산
+func luhnCheck(s string) byte {
+	factor, sum := 2, 0
+	for i := len(s) - 1; i >= 0; i-- {
+		addend := factor * int(base62Values[s[i]])
+		factor = 3 - factor
+		sum += addend/62 + addend%62
+	}
+	return base62Alphabet[(62-sum%62)%62]
+}
+
+// decodePublicBody - 본문의 Check 문자를 검증하고 ID 로 변환
+func decodePublicBody(prefix, body string) (uint64, error) {
+	data, check := body[:publicIDBodySize], body[publicIDBodySize]
+	id, ok := decodeBase62(data)
+	if !ok || base62Values[check] == 0xff {
+		return 0, fmt.Errorf("%w: malformed", ErrInvalidPublicID)
+	}
+	if luhnCheck(prefix+data) != check {
+		return 0, fmt.Errorf("%w: bad checksum", ErrInvalidPublicID)
+	}
+	return id, nil
+}
+
+// ===== [ Public Functions ] =====
+
+// RegisterKind - 지정한 이름과 접두어로 Kind 등록
+// conditions:
+// - 접두어는 소문자 영문 2 ~ 8 자리이며, `_` 는 자동으로 붙는다.
+// - 이름이나 접두어가 이미 등록되어 있으면 ErrDuplicateKind
+func RegisterKind(name, prefix string) (Kind, error) {
+	if name == "" || !validPrefix(prefix) {
+		return Kind{}, fmt.Errorf("%w: name %q, prefix %q", ErrInvalidKind, name, prefix)
+	}
+
+	kindsMu.Lock()
+	defer kindsMu.Unlock()
+
+	if _, ok := kinds[prefix]; ok || kindName[name] {
+		return Kind{}, fmt.Errorf("%w: name %q, prefix %q", ErrDuplicateKind, name, prefix)
+	}
+	k := Kind{Name: name, Prefix: prefix}
+	kinds[prefix] = k
+	kindName[name] = true
+	return k, nil
+}
+
+// MustRegisterKind - RegisterKind 로 Kind 를 등록하고 실패하면 Panic 처리
+// conditions:
+// - 패키지 변수 초기화에 사용한다. ex. `var User = id.MustRegisterKind("user", "usr")`
+func MustRegisterKind(name, prefix string) Kind {
+	k, err := RegisterKind(name, prefix)
+	if err != nil {
+		panic(err)
+	}
+	return k
+}
+
+// LookupKind - 지정한 접두어로 등록된 Kind 반환
+func LookupKind(prefix string) (Kind, bool) {
+	kindsMu.RLock()
+	defer kindsMu.RUnlock()
+
+	k, ok := kinds[gostrings.TrimSuffix(prefix, kindSeparator)]
+	return k, ok
+}
+
+// Kinds - 등록된 Kind 목록 반환
+func Kinds() []Kind {
+	kindsMu.RLock()
+	defer kindsMu.RUnlock()
+
+	result := make([]Kind, 0, len(kinds))
+	for _, k := range kinds {
+		result = append(result, k)
+	}
+	return result
+}
+
+// ParsePublicID - 접두어로 등록된 Kind 를 찾아 문자열을 PublicID 로 변환
+// conditions:
+// - 등록되지 않은 접두어는 ErrUnknownKind, 형식이나 Check 문자가 올바르지 않으면 ErrInvalidPublicID
+func ParsePublicID(s string) (PublicID, error) {
+	prefix, _, ok := splitPublicID(s)
+	if !ok {
+		return PublicID{}, fmt.Errorf("%w: malformed: %q", ErrInvalidPublicID, s)
+	}
+	k, ok := LookupKind(prefix)
+	if !ok {
+		return PublicID{}, fmt.Errorf("%w: %q", ErrUnknownKind, prefix)
+	}
+	return k.Parse(s)
+}
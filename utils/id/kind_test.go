@@ -0,0 +1,205 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package id
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// ===== [ Constants and Variables ] =====
+
+// testKind - Kind 등록은 전역이므로 패키지 테스트 전체에서 한번만 등록
+var (
+	testKind      = MustRegisterKind("kind-test", "ktest")
+	testOtherKind = MustRegisterKind("kind-test-other", "ktother")
+)
+
+// ===== [ Private Functions ] =====
+
+// replaceByte - 지정한 위치의 문자를 바꾼 문자열 반환
+func replaceByte(s string, i int, c byte) string {
+	b := []byte(s)
+	b[i] = c
+	return string(b)
+}
+
+// ===== [ Public Functions ] =====
+
+func TestRegisterKind(t *testing.T) {
+	if _, err := RegisterKind("kind-test", "ktesta"); !errors.Is(err, ErrDuplicateKind) {
+		t.Errorf("duplicate name returned %v, expected ErrDuplicateKind", err)
+	}
+	if _, err := RegisterKind("kind-test-dup", "ktest"); !errors.Is(err, ErrDuplicateKind) {
+		t.Errorf("duplicate prefix returned %v, expected ErrDuplicateKind", err)
+	}
+	for _, prefix := range []string{"", "k", "ktestlong", "Ktb", "kt_b", "kt1"} {
+		if _, err := RegisterKind("kind-test-invalid", prefix); !errors.Is(err, ErrInvalidKind) {
+			t.Errorf("prefix %q returned %v, expected ErrInvalidKind", prefix, err)
+		}
+	}
+	if _, err := RegisterKind("", "ktempty"); !errors.Is(err, ErrInvalidKind) {
+		t.Errorf("empty name returned %v, expected ErrInvalidKind", err)
+	}
+
+	k, ok := LookupKind("ktest_")
+	if !ok || k != testKind {
+		t.Fatalf("LookupKind returned (%v, %v), expected %v", k, ok, testKind)
+	}
+}
+
+func TestPublicIDRoundTrip(t *testing.T) {
+	for _, id := range []uint64{0, 1, 61, 62, 1 << 40, math.MaxUint64} {
+		s := testKind.FromID(id).String()
+		if len(s) != len("ktest_")+publicIDBodySize+1 {
+			t.Errorf("id %d: %q has unexpected length", id, s)
+		}
+		p, err := ParsePublicID(s)
+		if err != nil {
+			t.Errorf("id %d: ParsePublicID(%q) returned %v", id, s, err)
+			continue
+		}
+		if p.ID() != id || p.Kind() != testKind {
+			t.Errorf("id %d: parsed (%v, %d)", id, p.Kind(), p.ID())
+		}
+	}
+
+	// 문자열 순서와 ID 순서가 같아야 한다.
+	if a, b := testKind.FromID(61).String(), testKind.FromID(62).String(); a >= b {
+		t.Errorf("%q is not before %q", a, b)
+	}
+}
+
+func TestPublicIDOverflow(t *testing.T) {
+	// 11 자리 Base62 는 uint64 보다 크므로, Check 문자가 맞아도 범위를 넘으면 오류
+	body := "zzzzzzzzzzz"
+	s := "ktest_" + body + string(luhnCheck("ktest"+body))
+	if _, err := ParsePublicID(s); !errors.Is(err, ErrInvalidPublicID) {
+		t.Fatalf("ParsePublicID(%q) returned %v, expected ErrInvalidPublicID", s, err)
+	}
+}
+
+func TestPublicIDChecksum(t *testing.T) {
+	s := testKind.FromID(1234567890123).String()
+	body := len("ktest_")
+
+	// 본문과 Check 문자의 한 문자 변경
+	for i := body; i < len(s); i++ {
+		for j := 0; j < len(base62Alphabet); j++ {
+			c := base62Alphabet[j]
+			if c == s[i] {
+				continue
+			}
+			if p, err := ParsePublicID(replaceByte(s, i, c)); !errors.Is(err, ErrInvalidPublicID) {
+				t.Fatalf("changed %q at %d to %q: returned (%v, %v), expected ErrInvalidPublicID", s, i, c, p, err)
+			}
+		}
+	}
+
+	// 인접한 문자 교환
+	for i := body; i < len(s)-1; i++ {
+		if s[i] == s[i+1] {
+			continue
+		}
+		swapped := replaceByte(replaceByte(s, i, s[i+1]), i+1, s[i])
+		if _, err := ParsePublicID(swapped); !errors.Is(err, ErrInvalidPublicID) {
+			t.Errorf("swapped %q at %d (%q): returned %v, expected ErrInvalidPublicID", s, i, swapped, err)
+		}
+	}
+}
+
+func TestPublicIDParseRejects(t *testing.T) {
+	other := testOtherKind
+	s := other.FromID(42).String()
+
+	if _, err := testKind.Parse(s); !errors.Is(err, ErrInvalidPublicID) {
+		t.Errorf("Parse with wrong prefix returned %v, expected ErrInvalidPublicID", err)
+	}
+	if p, err := ParsePublicID(s); err != nil || p.Kind() != other {
+		t.Errorf("ParsePublicID(%q) returned (%v, %v), expected kind %v", s, p, err, other)
+	}
+
+	unknown := "kunknown" + s[len("ktother"):]
+	if _, err := ParsePublicID(unknown); !errors.Is(err, ErrUnknownKind) {
+		t.Errorf("ParsePublicID(%q) returned %v, expected ErrUnknownKind", unknown, err)
+	}
+	for _, malformed := range []string{"", "ktest", "ktest_", "_0000000000A0", s[:len(s)-1], s + "0", "ktest_00000000-000"} {
+		if _, err := ParsePublicID(malformed); !errors.Is(err, ErrInvalidPublicID) {
+			t.Errorf("ParsePublicID(%q) returned %v, expected ErrInvalidPublicID", malformed, err)
+		}
+	}
+}
+
+func TestKindNewWithInvalidPrefix(t *testing.T) {
+	g := newTestGenerator(t, &fakeClock{now: time.Unix(1700000000, 0)}, 1)
+	for _, k := range []Kind{{}, {Name: "bad", Prefix: "Bad"}} {
+		if p, err := k.NewWith(g); !errors.Is(err, ErrInvalidKind) {
+			t.Errorf("kind %+v: NewWith returned (%v, %v), expected ErrInvalidKind", k, p, err)
+		}
+	}
+
+	p, err := testKind.NewWith(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed, err := ParsePublicID(p.String()); err != nil || parsed != p {
+		t.Fatalf("ParsePublicID(%q) returned (%v, %v), expected %v", p, parsed, err, p)
+	}
+}
+
+func TestPublicIDJSON(t *testing.T) {
+	type record struct {
+		ID PublicID `json:"id"`
+	}
+
+	data, err := json.Marshal(record{})
+	if err != nil || string(data) != `{"id":null}` {
+		t.Fatalf("zero value marshaled to (%s, %v), expected null", data, err)
+	}
+	r := record{ID: testKind.FromID(7)}
+	if err := json.Unmarshal([]byte(`{"id":null}`), &r); err != nil || !r.ID.IsZero() {
+		t.Fatalf("null unmarshaled to (%v, %v), expected zero value", r.ID, err)
+	}
+
+	want := record{ID: testKind.FromID(7)}
+	if data, err = json.Marshal(want); err != nil {
+		t.Fatal(err)
+	}
+	var got record
+	if err := json.Unmarshal(data, &got); err != nil || got != want {
+		t.Fatalf("%s unmarshaled to (%v, %v), expected %v", data, got, err, want)
+	}
+	if err := json.Unmarshal([]byte(`{"id":7}`), &got); !errors.Is(err, ErrInvalidPublicID) {
+		t.Fatalf("number unmarshaled with %v, expected ErrInvalidPublicID", err)
+	}
+}
+
+func TestPublicIDSQL(t *testing.T) {
+	if v, err := (PublicID{}).Value(); v != nil || err != nil {
+		t.Fatalf("zero value Value returned (%v, %v), expected NULL", v, err)
+	}
+	want := testKind.FromID(99)
+	v, err := want.Value()
+	if err != nil || v != want.String() {
+		t.Fatalf("Value returned (%v, %v), expected %q", v, err, want.String())
+	}
+
+	for _, src := range []interface{}{want.String(), []byte(want.String())} {
+		var p PublicID
+		if err := p.Scan(src); err != nil || p != want {
+			t.Errorf("Scan(%T) returned (%v, %v), expected %v", src, p, err, want)
+		}
+	}
+
+	p := want
+	if err := p.Scan(nil); err != nil || !p.IsZero() {
+		t.Errorf("Scan(nil) returned (%v, %v), expected zero value", p, err)
+	}
+	if err := p.Scan(int64(99)); !errors.Is(err, ErrInvalidPublicID) {
+		t.Errorf("Scan(int64) returned %v, expected ErrInvalidPublicID", err)
+	}
+}
@@ -0,0 +1,394 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package id
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog"
+)
+
+// ===== [ Constants and Variables ] =====
+const (
+	DefaultLeasePrefix        = "machine-id-"    // Lease 이름 기본 접두어 (`<prefix><machine id>`)
+	DefaultLeasePoolSize      = 1024             // 기본 머신 ID Pool 크기
+	DefaultLeaseDuration      = 30 * time.Second // 기본 Lease 유효 기간
+	DefaultLeaseRenewInterval = 10 * time.Second // 기본 Lease 갱신 주기
+	DefaultLeaseSafetyMargin  = 2 * time.Second  // Lease 만료 전에 머신 ID 사용을 중단하는 기본 여유 시간
+)
+
+var (
+	ErrLeaseLost       = errors.New("machine id lease lost")           // Lease 를 잃어 머신 ID 를 더 이상 사용할 수 없는 경우
+	ErrNoFreeMachineID = errors.New("no free machine id in pool")      // Pool 의 모든 머신 ID 가 사용 중인 경우
+	ErrInvalidLease    = errors.New("invalid machine id lease option") // Lease 옵션이 올바르지 않은 경우
+)
+
+// ========== [ MachineIDLease START ] =========
+
+// LeaseOptions - Kubernetes Lease 기반 머신 ID 할당 옵션
+type LeaseOptions struct {
+	Client        kubernetes.Interface // Kubernetes Client (client-go fake clientset 사용 가능)
+	Namespace     string               // Lease 를 생성할 Namespace
+	Prefix        string               // Lease 이름 접두어 (빈 값이면 DefaultLeasePrefix)
+	Identity      string               // Lease 소유자 식별자 (빈 값이면 획득할 때마다 `<hostname>-<난수>` 로 생성)
+	Resume        bool                 // 같은 Identity 로 유지 중인 유효한 Lease 를 이어서 사용할지 여부 (재시작 전의 머신 ID 를 다시 사용하는 경우, 고정된 Identity 필요)
+	PoolSize      int                  // 머신 ID Pool 크기, 0 ~ PoolSize-1 범위를 사용 (0 이하면 DefaultLeasePoolSize)
+	LeaseDuration time.Duration        // Lease 유효 기간, 초 단위여야 함 (0 이하면 DefaultLeaseDuration)
+	RenewInterval time.Duration        // Lease 갱신 주기 (0 이하면 DefaultLeaseRenewInterval)
+	SafetyMargin  time.Duration        // Lease 만료 전에 머신 ID 사용을 중단하는 여유 시간, 노드 간 시각 차이보다 커야 함 (0 이하면 DefaultLeaseSafetyMargin)
+	Clock         func() time.Time     // 현재 시각 (nil 이면 time.Now)
+}
+
+// normalize - 기본값 적용 및 검증
+func (o *LeaseOptions) normalize() error {
+	if o.Client == nil || o.Namespace == "" {
+		return fmt.Errorf("%w: client and namespace are required", ErrInvalidLease)
+	}
+	if o.Prefix == "" {
+		o.Prefix = DefaultLeasePrefix
+	}
+	// 같은 Pod 의 Container 들이나 같은 프로세스의 여러 획득이 서로 다른 소유자가 되도록 난수를 붙인다.
+	if o.Identity == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return err
+		}
+		var suffix [8]byte
+		if _, err := rand.Read(suffix[:]); err != nil {
+			return err
+		}
+		o.Identity = hostname + "-" + hex.EncodeToString(suffix[:])
+	}
+	if o.PoolSize <= 0 {
+		o.PoolSize = DefaultLeasePoolSize
+	}
+	if o.PoolSize > 1<<BitLenMachineID {
+		return fmt.Errorf("%w: pool size %d exceeds %d", ErrInvalidLease, o.PoolSize, 1<<BitLenMachineID)
+	}
+	if o.LeaseDuration <= 0 {
+		o.LeaseDuration = DefaultLeaseDuration
+	}
+	// Lease 에는 초 단위로만 기록되므로 나머지가 있으면 다른 인스턴스가 더 일찍 만료로 판단한다.
+	if o.LeaseDuration%time.Second != 0 {
+		return fmt.Errorf("%w: lease duration %v is not a whole number of seconds", ErrInvalidLease, o.LeaseDuration)
+	}
+	if o.RenewInterval <= 0 {
+		o.RenewInterval = DefaultLeaseRenewInterval
+	}
+	if o.SafetyMargin <= 0 {
+		o.SafetyMargin = DefaultLeaseSafetyMargin
+	}
+	if o.RenewInterval+o.SafetyMargin >= o.LeaseDuration {
+		return fmt.Errorf("%w: renew interval and safety margin must be shorter than lease duration", ErrInvalidLease)
+	}
+	if o.Clock == nil {
+		o.Clock = time.Now
+	}
+	return nil
+}
+
+// MachineIDLease - Kubernetes Lease 로 할당받은 머신 ID 정보 관리용
+// conditions:
+// - 백그라운드에서 RenewInterval 마다 Lease 를 갱신한다.
+// - 다른 소유자가 Lease 를 가져가거나, 마지막으로 기록한 RenewTime + LeaseDuration - SafetyMargin 까지 갱신하지 못하면 Lease 를 잃은 것으로 판단한다.
+// - 다른 인스턴스는 RenewTime + LeaseDuration 이후에 인수하므로, 노드 간 시각 차이가 SafetyMargin 보다 작으면 같은 머신 ID 를 동시에 사용하지 않는다.
+// - Lease 를 잃은 후에는 다시 얻지 않으며, 이 Lease 로 만든 Generator 는 ErrLeaseLost 를 반환한다.
+type MachineIDLease struct {
+	opts      LeaseOptions
+	machineID uint16
+	name      string
+
+	mu        sync.Mutex
+	lastRenew time.Time // Lease 에 마지막으로 기록된 RenewTime
+	err       error
+
+	lost   chan struct{}
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// MachineID - 할당받은 머신 ID 반환
+func (l *MachineIDLease) MachineID() uint16 {
+	return l.machineID
+}
+
+// Identity - Lease 소유자 식별자 반환
+func (l *MachineIDLease) Identity() string {
+	return l.opts.Identity
+}
+
+// Name - Lease 이름 반환
+func (l *MachineIDLease) Name() string {
+	return l.name
+}
+
+// Lost - Lease 를 잃으면 닫히는 채널 반환
+func (l *MachineIDLease) Lost() <-chan struct{} {
+	return l.lost
+}
+
+// Err - Lease 를 잃은 경우 ErrLeaseLost 반환, 유효하면 nil
+// conditions:
+// - 갱신 주기 사이에도 마지막 RenewTime 으로부터 LeaseDuration - SafetyMargin 이 지나면 Lease 를 잃은 것으로 판단한다.
+func (l *MachineIDLease) Err() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.err == nil && l.expired() {
+		l.markLost(fmt.Errorf("%w: not renewed within %v", ErrLeaseLost, l.opts.LeaseDuration-l.opts.SafetyMargin))
+	}
+	return l.err
+}
+
+// NewGenerator - 할당받은 머신 ID 를 사용하고 Lease 를 잃으면 ID 생성을 중단하는 Generator 생성
+// conditions:
+// - 지정한 옵션의 MachineID 와 Guard 는 무시된다.
+func (l *MachineIDLease) NewGenerator(opts Options) (*Generator, error) {
+	opts.MachineID = StaticMachineID(l.machineID)
+	opts.Guard = l.Err
+	return NewGenerator(opts)
+}
+
+// Release - 갱신을 중단하고 Lease 를 삭제해서 머신 ID 를 반환
+// conditions:
+// - 다른 소유자에게 넘어간 Lease 는 삭제하지 않는다.
+func (l *MachineIDLease) Release(ctx context.Context) error {
+	l.cancel()
+	<-l.done
+
+	l.mu.Lock()
+	if l.err == nil {
+		l.err = fmt.Errorf("%w: released", ErrLeaseLost)
+		close(l.lost)
+	}
+	l.mu.Unlock()
+
+	leases := l.opts.Client.CoordinationV1().Leases(l.opts.Namespace)
+	lease, err := leases.Get(ctx, l.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !heldBy(lease, l.opts.Identity) {
+		return nil
+	}
+
+	err = leases.Delete(ctx, l.name, metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{UID: &lease.UID, ResourceVersion: &lease.ResourceVersion},
+	})
+	if apierrors.IsNotFound(err) || apierrors.IsConflict(err) {
+		return nil
+	}
+	return err
+}
+
+// run - RenewInterval 마다 Lease 갱신
+func (l *MachineIDLease) run(ctx context.Context) {
+	defer close(l.done)
+
+	ticker := time.NewTicker(l.opts.RenewInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		renewTime, err := l.renew(ctx)
+		l.mu.Lock()
+		switch {
+		case l.err != nil:
+		case errors.Is(err, ErrLeaseLost):
+			l.markLost(err)
+		case err != nil:
+			klog.Warningf("failed to renew machine id lease %s/%s: %v", l.opts.Namespace, l.name, err)
+			if l.expired() {
+				l.markLost(fmt.Errorf("%w: %v", ErrLeaseLost, err))
+			}
+		default:
+			l.lastRenew = renewTime
+		}
+		lost := l.err != nil
+		l.mu.Unlock()
+
+		if lost {
+			return
+		}
+	}
+}
+
+// renew - Lease 의 갱신 시각을 변경하고 기록한 RenewTime 반환
+func (l *MachineIDLease) renew(ctx context.Context) (time.Time, error) {
+	leases := l.opts.Client.CoordinationV1().Leases(l.opts.Namespace)
+	lease, err := leases.Get(ctx, l.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return time.Time{}, fmt.Errorf("%w: %s deleted", ErrLeaseLost, l.name)
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !heldBy(lease, l.opts.Identity) {
+		return time.Time{}, fmt.Errorf("%w: %s taken by another holder", ErrLeaseLost, l.name)
+	}
+
+	now := leaseNow(l.opts)
+	lease.Spec.RenewTime = &now
+	if _, err = leases.Update(ctx, lease, metav1.UpdateOptions{}); err != nil {
+		return time.Time{}, err
+	}
+	return now.Time, nil
+}
+
+// expired - 마지막 RenewTime 기준으로 머신 ID 를 더 이상 사용할 수 없는지 여부 (mu 잠금 상태에서 호출)
+func (l *MachineIDLease) expired() bool {
+	return !l.opts.Clock().Before(l.lastRenew.Add(l.opts.LeaseDuration - l.opts.SafetyMargin))
+}
+
+// markLost - Lease 를 잃은 상태로 변경 (mu 잠금 상태에서 호출)
+func (l *MachineIDLease) markLost(err error) {
+	klog.Errorf("machine id %d lease %s/%s lost: %v", l.machineID, l.opts.Namespace, l.name, err)
+	l.err = err
+	close(l.lost)
+}
+
+// ========== [ MachineIDLease END ] =========
+
+// ===== [ Private Functions ] =====
+
+// heldBy - 지정한 소유자가 Lease 를 가지고 있는지 여부
+func heldBy(lease *coordinationv1.Lease, identity string) bool {
+	return lease.Spec.HolderIdentity != nil && *lease.Spec.HolderIdentity == identity
+}
+
+// leaseNow - Lease 에 기록할 현재 시각 반환
+// conditions:
+// - MicroTime 은 마이크로초 단위로 저장되므로 미리 잘라서 기록한 값과 보관하는 값이 같도록 한다.
+func leaseNow(opts LeaseOptions) metav1.MicroTime {
+	return metav1.NewMicroTime(opts.Clock().Truncate(time.Microsecond))
+}
+
+// leaseExpired - Lease 유효 기간이 지났는지 여부
+func leaseExpired(lease *coordinationv1.Lease, now time.Time) bool {
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity == "" {
+		return true
+	}
+	renew := lease.Spec.RenewTime
+	if renew == nil {
+		renew = lease.Spec.AcquireTime
+	}
+	if renew == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return true
+	}
+	return now.After(renew.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second))
+}
+
+// tryAcquire - 지정한 이름의 Lease 획득 시도
+// conditions:
+// - 획득한 경우는 Lease 에 기록한 RenewTime 을 함께 반환한다.
+// - 유효한 Lease 가 있거나 (같은 Identity 는 Resume 인 경우 제외), 동시에 다른 소유자가 획득한 경우는 false 반환
+func tryAcquire(ctx context.Context, opts LeaseOptions, name string) (time.Time, bool, error) {
+	leases := opts.Client.CoordinationV1().Leases(opts.Namespace)
+	microNow := leaseNow(opts)
+	now := microNow.Time
+	duration := int32(opts.LeaseDuration / time.Second)
+
+	lease, err := leases.Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = leases.Create(ctx, &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: opts.Namespace},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       &opts.Identity,
+				LeaseDurationSeconds: &duration,
+				AcquireTime:          &microNow,
+				RenewTime:            &microNow,
+			},
+		}, metav1.CreateOptions{})
+		if apierrors.IsAlreadyExists(err) {
+			return time.Time{}, false, nil
+		}
+		return now, err == nil, err
+	}
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	// 유효한 Lease 는 같은 Identity 라도 Resume 으로 지정한 경우만 다시 사용
+	held := heldBy(lease, opts.Identity)
+	if !leaseExpired(lease, now) && !(held && opts.Resume) {
+		return time.Time{}, false, nil
+	}
+
+	// 만료된 Lease 인수 (ResourceVersion 으로 동시 인수 방지)
+	if !held {
+		transitions := int32(1)
+		if lease.Spec.LeaseTransitions != nil {
+			transitions = *lease.Spec.LeaseTransitions + 1
+		}
+		lease.Spec.LeaseTransitions = &transitions
+		lease.Spec.AcquireTime = &microNow
+	}
+	lease.Spec.HolderIdentity = &opts.Identity
+	lease.Spec.LeaseDurationSeconds = &duration
+	lease.Spec.RenewTime = &microNow
+
+	_, err = leases.Update(ctx, lease, metav1.UpdateOptions{})
+	if apierrors.IsConflict(err) {
+		return time.Time{}, false, nil
+	}
+	return now, err == nil, err
+}
+
+// ===== [ Public Functions ] =====
+
+// AcquireMachineIDLease - Pool 에서 사용 가능한 머신 ID 의 `coordination.k8s.io/v1` Lease 를 획득하고 백그라운드 갱신 시작
+// conditions:
+// - 0 부터 순서대로 Lease 가 없거나 만료된 머신 ID 를 할당하며, Resume 인 경우는 같은 Identity 로 유지 중인 Lease 도 할당한다.
+// - Identity 를 지정하지 않으면 획득할 때마다 다른 Identity 를 사용하므로, 같은 Pod 나 프로세스에서 여러 번 획득해도 서로 다른 머신 ID 가 할당된다.
+// - 사용 가능한 머신 ID 가 없으면 ErrNoFreeMachineID
+// - 사용을 마치면 Release 를 호출해야 한다.
+func AcquireMachineIDLease(ctx context.Context, opts LeaseOptions) (*MachineIDLease, error) {
+	if err := opts.normalize(); err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < opts.PoolSize; i++ {
+		name := fmt.Sprintf("%s%d", opts.Prefix, i)
+		renewTime, ok, err := tryAcquire(ctx, opts, name)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		renewCtx, cancel := context.WithCancel(context.Background())
+		l := &MachineIDLease{
+			opts:      opts,
+			machineID: uint16(i),
+			name:      name,
+			lastRenew: renewTime,
+			lost:      make(chan struct{}),
+			cancel:    cancel,
+			done:      make(chan struct{}),
+		}
+		go l.run(renewCtx)
+		return l, nil
+	}
+	return nil, ErrNoFreeMachineID
+}
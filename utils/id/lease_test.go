@@ -0,0 +1,259 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package id
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// ===== [ Constants and Variables ] =====
+const (
+	testLeaseNamespace = "default"
+)
+
+// ===== [ Private Functions ] =====
+
+// acquireTestLease - 지정한 Client 로 Lease 를 획득하고 테스트 종료 시 반환
+func acquireTestLease(t *testing.T, client kubernetes.Interface, opts LeaseOptions) *MachineIDLease {
+	opts.Client = client
+	opts.Namespace = testLeaseNamespace
+	l, err := AcquireMachineIDLease(context.Background(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Release(context.Background()) })
+	return l
+}
+
+// createTestLease - 지정한 소유자와 갱신 시각으로 Lease 생성
+func createTestLease(t *testing.T, client kubernetes.Interface, name, holder string, renew time.Time) {
+	duration := int32(DefaultLeaseDuration / time.Second)
+	renewTime := metav1.NewMicroTime(renew)
+	_, err := client.CoordinationV1().Leases(testLeaseNamespace).Create(context.Background(), &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testLeaseNamespace},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       &holder,
+			LeaseDurationSeconds: &duration,
+			AcquireTime:          &renewTime,
+			RenewTime:            &renewTime,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// getTestLease - 지정한 이름의 Lease 조회
+func getTestLease(t *testing.T, client kubernetes.Interface, name string) *coordinationv1.Lease {
+	lease, err := client.CoordinationV1().Leases(testLeaseNamespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return lease
+}
+
+// ===== [ Public Functions ] =====
+
+func TestAcquireMachineIDLeaseFirstFree(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	l := acquireTestLease(t, client, LeaseOptions{Identity: "a"})
+
+	if l.MachineID() != 0 || l.Name() != DefaultLeasePrefix+"0" {
+		t.Fatalf("acquired machine id %d (%s), expected 0", l.MachineID(), l.Name())
+	}
+	lease := getTestLease(t, client, l.Name())
+	if !heldBy(lease, "a") || *lease.Spec.LeaseDurationSeconds != int32(DefaultLeaseDuration/time.Second) {
+		t.Fatalf("lease spec = %+v, expected holder a with default duration", lease.Spec)
+	}
+	if !l.lastRenew.Equal(lease.Spec.RenewTime.Time) {
+		t.Fatalf("lastRenew %v differs from written RenewTime %v", l.lastRenew, lease.Spec.RenewTime.Time)
+	}
+}
+
+func TestAcquireMachineIDLeaseSkipsHeld(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	createTestLease(t, client, DefaultLeasePrefix+"0", "other", time.Now())
+
+	l := acquireTestLease(t, client, LeaseOptions{Identity: "a"})
+	if l.MachineID() != 1 {
+		t.Fatalf("acquired machine id %d, expected 1", l.MachineID())
+	}
+	if lease := getTestLease(t, client, DefaultLeasePrefix+"0"); !heldBy(lease, "other") {
+		t.Fatal("valid lease of another holder was taken")
+	}
+}
+
+func TestAcquireMachineIDLeaseTakesExpired(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	createTestLease(t, client, DefaultLeasePrefix+"0", "other", time.Now().Add(-2*DefaultLeaseDuration))
+
+	l := acquireTestLease(t, client, LeaseOptions{Identity: "a"})
+	if l.MachineID() != 0 {
+		t.Fatalf("acquired machine id %d, expected expired slot 0", l.MachineID())
+	}
+	lease := getTestLease(t, client, l.Name())
+	if !heldBy(lease, "a") || lease.Spec.LeaseTransitions == nil || *lease.Spec.LeaseTransitions != 1 {
+		t.Fatalf("lease spec = %+v, expected holder a with 1 transition", lease.Spec)
+	}
+}
+
+func TestAcquireMachineIDLeaseUnique(t *testing.T) {
+	// 기본 Identity (Hostname 기반) 와 같은 Identity 모두 서로 다른 머신 ID 를 할당받아야 한다.
+	for _, identity := range []string{"", "same"} {
+		client := fake.NewSimpleClientset()
+		a := acquireTestLease(t, client, LeaseOptions{Identity: identity})
+		b := acquireTestLease(t, client, LeaseOptions{Identity: identity})
+		if a.MachineID() == b.MachineID() {
+			t.Fatalf("identity %q: two acquisitions got the same machine id %d", identity, a.MachineID())
+		}
+		if identity == "" && a.Identity() == b.Identity() {
+			t.Fatalf("default identities are not unique: %s", a.Identity())
+		}
+	}
+}
+
+func TestAcquireMachineIDLeaseResume(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	createTestLease(t, client, DefaultLeasePrefix+"0", "a", time.Now())
+
+	l := acquireTestLease(t, client, LeaseOptions{Identity: "a", Resume: true})
+	if l.MachineID() != 0 {
+		t.Fatalf("resumed machine id %d, expected 0", l.MachineID())
+	}
+}
+
+func TestMachineIDLeaseLostOnHolderChange(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	l := acquireTestLease(t, client, LeaseOptions{
+		Identity:      "a",
+		LeaseDuration: 2 * time.Second,
+		RenewInterval: 10 * time.Millisecond,
+		SafetyMargin:  500 * time.Millisecond,
+	})
+	g, err := l.NewGenerator(Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.NextID(); err != nil {
+		t.Fatal(err)
+	}
+
+	// fake clientset 은 ResourceVersion 을 검증하지 않으므로 갱신과 겹쳐 덮어써질 수 있어 Lost 까지 반복한다.
+	leases := client.CoordinationV1().Leases(testLeaseNamespace)
+	timeout := time.After(5 * time.Second)
+	for lost := false; !lost; {
+		lease := getTestLease(t, client, l.Name())
+		holder := "other"
+		lease.Spec.HolderIdentity = &holder
+		if _, err := leases.Update(context.Background(), lease, metav1.UpdateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case <-l.Lost():
+			lost = true
+		case <-time.After(50 * time.Millisecond):
+		case <-timeout:
+			t.Fatal("lease was not lost after holder change")
+		}
+	}
+
+	if err := l.Err(); !errors.Is(err, ErrLeaseLost) {
+		t.Fatalf("Err returned %v, expected ErrLeaseLost", err)
+	}
+	if _, err := g.NextID(); !errors.Is(err, ErrLeaseLost) {
+		t.Fatalf("NextID after lease lost returned %v, expected ErrLeaseLost", err)
+	}
+
+	// 다른 소유자의 Lease 는 Release 로 삭제하지 않는다.
+	if err := l.Release(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if lease := getTestLease(t, client, l.Name()); !heldBy(lease, "other") {
+		t.Fatal("Release removed the lease of another holder")
+	}
+}
+
+func TestMachineIDLeaseExpiresBeforeOthersCanTake(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	var mu sync.Mutex
+	now := time.Now()
+	clock := func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	setNow := func(t time.Time) {
+		mu.Lock()
+		defer mu.Unlock()
+		now = t
+	}
+	l := acquireTestLease(t, client, LeaseOptions{Identity: "a", Clock: clock})
+
+	// 소유자는 RenewTime + LeaseDuration - SafetyMargin 에 사용을 중단한다.
+	expiry := l.lastRenew.Add(DefaultLeaseDuration - DefaultLeaseSafetyMargin)
+	setNow(expiry.Add(-time.Microsecond))
+	if err := l.Err(); err != nil {
+		t.Fatalf("lease lost before safety margin: %v", err)
+	}
+	setNow(expiry)
+	if err := l.Err(); !errors.Is(err, ErrLeaseLost) {
+		t.Fatalf("Err at safety margin returned %v, expected ErrLeaseLost", err)
+	}
+
+	// 다른 인스턴스는 아직 인수할 수 없다.
+	other := LeaseOptions{Client: client, Namespace: testLeaseNamespace, Identity: "b", Clock: clock}
+	if err := other.normalize(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, err := tryAcquire(context.Background(), other, l.Name()); err != nil || ok {
+		t.Fatalf("other instance acquired (%v, %v) while lease is still valid", ok, err)
+	}
+}
+
+func TestMachineIDLeaseRelease(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	l := acquireTestLease(t, client, LeaseOptions{Identity: "a"})
+
+	if err := l.Release(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Err(); !errors.Is(err, ErrLeaseLost) {
+		t.Fatalf("Err after Release returned %v, expected ErrLeaseLost", err)
+	}
+	_, err := client.CoordinationV1().Leases(testLeaseNamespace).Get(context.Background(), l.Name(), metav1.GetOptions{})
+	if !apierrors.IsNotFound(err) {
+		t.Fatalf("lease still exists after Release: %v", err)
+	}
+
+	// 반환된 머신 ID 는 다른 소유자가 바로 할당받을 수 있다.
+	next := acquireTestLease(t, client, LeaseOptions{Identity: "b"})
+	if next.MachineID() != l.MachineID() {
+		t.Fatalf("acquired machine id %d after Release, expected %d", next.MachineID(), l.MachineID())
+	}
+}
+
+func TestLeaseOptionsInvalid(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	for name, opts := range map[string]LeaseOptions{
+		"no client":        {Namespace: testLeaseNamespace},
+		"fraction":         {Client: client, Namespace: testLeaseNamespace, LeaseDuration: 1500 * time.Millisecond},
+		"renew too long":   {Client: client, Namespace: testLeaseNamespace, LeaseDuration: 10 * time.Second, RenewInterval: 9 * time.Second},
+		"pool size":        {Client: client, Namespace: testLeaseNamespace, PoolSize: 1<<BitLenMachineID + 1},
+		"margin too large": {Client: client, Namespace: testLeaseNamespace, LeaseDuration: 10 * time.Second, RenewInterval: time.Second, SafetyMargin: 9 * time.Second},
+	} {
+		if _, err := AcquireMachineIDLease(context.Background(), opts); !errors.Is(err, ErrInvalidLease) {
+			t.Errorf("%s: AcquireMachineIDLease returned %v, expected ErrInvalidLease", name, err)
+		}
+	}
+}
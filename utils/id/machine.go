@@ -0,0 +1,243 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package id
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"strconv"
+	gostrings "strings"
+	"sync"
+)
+
+// ===== [ Constants and Variables ] =====
+const (
+	DefaultMachineIDEnv = "MACHINE_ID" // 머신 ID 를 지정하는 기본 환경 변수
+	PodUIDEnv           = "POD_UID"    // Downward API 로 전달되는 Pod UID 환경 변수
+	PodNameEnv          = "POD_NAME"   // Downward API 로 전달되는 Pod 이름 환경 변수
+)
+
+var (
+	ErrDuplicateMachineID = errors.New("duplicate machine id")         // 다른 인스턴스가 이미 사용 중인 머신 ID 인 경우
+	ErrMachineIDNotSet    = errors.New("machine id source is not set") // 제공 함수가 사용하는 정보 (환경 변수, 주소 등) 가 없는 경우
+)
+
+// ========== [ MachineIDRegistry START ] =========
+
+// MachineIDRegistry - Generator 시작 시 머신 ID 중복을 검증하기 위한 Registry
+// conditions:
+// - 여러 Pod 간의 중복 검증은 공유 저장소가 필요하므로, Kubernetes 환경에서는 AcquireMachineIDLease 로 Lease 기반의 머신 ID 를 할당받아야 한다.
+type MachineIDRegistry interface {
+	// Register - 지정한 머신 ID 를 사용 중으로 등록, 다른 인스턴스가 사용 중이면 ErrDuplicateMachineID
+	Register(machineID uint16) error
+}
+
+// MemoryRegistry - 같은 프로세스 내의 Generator 들의 머신 ID 중복을 검증하는 Registry
+// conditions:
+// - 동시 사용에 안전하며, Zero 값을 그대로 사용할 수 있다.
+// - 다른 프로세스나 Pod 와의 중복은 검증하지 못한다.
+type MemoryRegistry struct {
+	mu  sync.Mutex
+	ids map[uint16]bool
+}
+
+// Register - 지정한 머신 ID 를 사용 중으로 등록
+func (r *MemoryRegistry) Register(machineID uint16) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.ids[machineID] {
+		return fmt.Errorf("%w: %d", ErrDuplicateMachineID, machineID)
+	}
+	if r.ids == nil {
+		r.ids = map[uint16]bool{}
+	}
+	r.ids[machineID] = true
+	return nil
+}
+
+// Release - 지정한 머신 ID 등록 해제
+func (r *MemoryRegistry) Release(machineID uint16) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.ids, machineID)
+}
+
+// ========== [ MachineIDRegistry END ] =========
+
+// ===== [ Private Functions ] =====
+
+// hashMachineID - 지정한 문자열의 FNV-1a 32 bit Hash 를 16 bit 로 접어서 반환
+func hashMachineID(value string) uint16 {
+	h := fnv.New32a()
+	h.Write([]byte(value))
+	sum := h.Sum32()
+	return uint16(sum>>16) ^ uint16(sum)
+}
+
+// ===== [ Public Functions ] =====
+
+// NewMemoryRegistry - MemoryRegistry 생성
+func NewMemoryRegistry() *MemoryRegistry {
+	return &MemoryRegistry{ids: map[uint16]bool{}}
+}
+
+// DefaultMachineID - 기본 머신 ID 제공 함수 반환
+// conditions:
+// - DefaultMachineIDEnv 환경 변수, 사설 IPv4 주소, 첫번째 IPv4 주소, 전역 IPv6 주소 순서로 처음 구해지는 값을 사용한다.
+// - DefaultMachineIDEnv 환경 변수가 설정되었지만 값이 올바르지 않으면 주소로 대체하지 않고 ErrInvalidMachineID 반환
+func DefaultMachineID() MachineIDFunc {
+	return ChainMachineID(
+		MachineIDFromEnv(DefaultMachineIDEnv),
+		MachineIDFromPrivateIPv4(),
+		MachineIDFromIPv4(),
+		MachineIDFromIPv6(),
+	)
+}
+
+// ChainMachineID - 지정한 제공 함수들을 순서대로 호출해서 처음 성공한 머신 ID 를 반환하는 제공 함수 반환
+// conditions:
+// - ErrMachineIDNotSet 인 경우만 다음 제공 함수를 시도하며, 그 외 오류 (ex. 잘못된 MACHINE_ID 값) 는 그대로 반환한다.
+// - 모두 ErrMachineIDNotSet 이면 각 오류를 포함한 ErrNoMachineID 반환
+func ChainMachineID(providers ...MachineIDFunc) MachineIDFunc {
+	return func() (uint16, error) {
+		var msgs []string
+		for _, provider := range providers {
+			id, err := provider()
+			if err == nil {
+				return id, nil
+			}
+			if !errors.Is(err, ErrMachineIDNotSet) {
+				return 0, err
+			}
+			msgs = append(msgs, err.Error())
+		}
+		return 0, fmt.Errorf("%w: %s", ErrNoMachineID, gostrings.Join(msgs, "; "))
+	}
+}
+
+// StaticMachineID - 지정한 머신 ID 를 반환하는 제공 함수 반환
+func StaticMachineID(machineID uint16) MachineIDFunc {
+	return func() (uint16, error) {
+		return machineID, nil
+	}
+}
+
+// MachineIDFromEnv - 지정한 환경 변수의 값 (0 ~ 65535) 을 머신 ID 로 사용하는 제공 함수 반환
+// conditions:
+// - 환경 변수가 없으면 ErrMachineIDNotSet, 값이 올바르지 않으면 ErrInvalidMachineID
+func MachineIDFromEnv(name string) MachineIDFunc {
+	return func() (uint16, error) {
+		value, ok := os.LookupEnv(name)
+		if !ok || value == "" {
+			return 0, fmt.Errorf("%w: environment variable %s", ErrMachineIDNotSet, name)
+		}
+		id, err := strconv.ParseUint(gostrings.TrimSpace(value), 10, 16)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s=%s", ErrInvalidMachineID, name, value)
+		}
+		return uint16(id), nil
+	}
+}
+
+// MachineIDFromStatefulSetOrdinal - StatefulSet Pod 의 Hostname (`<name>-<ordinal>`) 에서 순번을 머신 ID 로 사용하는 제공 함수 반환
+// conditions:
+// - Hostname 이 빈 값이면 os.Hostname 을 사용한다.
+// - 여러 StatefulSet 이 같은 범위를 사용하지 않도록 순번에 offset 을 더한다.
+// - Hostname 에 순번이 없으면 ErrMachineIDNotSet, 순번에 offset 을 더한 값이 범위를 넘으면 ErrInvalidMachineID
+func MachineIDFromStatefulSetOrdinal(hostname string, offset uint16) MachineIDFunc {
+	return func() (uint16, error) {
+		name := hostname
+		if name == "" {
+			var err error
+			if name, err = os.Hostname(); err != nil {
+				return 0, err
+			}
+		}
+		// FQDN 인 경우는 첫번째 이름만 사용
+		if idx := gostrings.IndexByte(name, '.'); idx >= 0 {
+			name = name[:idx]
+		}
+
+		idx := gostrings.LastIndexByte(name, '-')
+		if idx < 0 {
+			return 0, fmt.Errorf("%w: hostname %s has no ordinal", ErrMachineIDNotSet, name)
+		}
+		ordinal, err := strconv.ParseUint(name[idx+1:], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: hostname %s has no ordinal", ErrMachineIDNotSet, name)
+		}
+		if ordinal+uint64(offset) > 1<<BitLenMachineID-1 {
+			return 0, fmt.Errorf("%w: hostname %s ordinal %d with offset %d is out of range", ErrInvalidMachineID, name, ordinal, offset)
+		}
+		return uint16(ordinal) + offset, nil
+	}
+}
+
+// MachineIDFromPodIdentity - Pod UID, Pod 이름, Hostname 순서로 처음 구해지는 값의 Hash 를 머신 ID 로 사용하는 제공 함수 반환
+// conditions:
+// - PodUIDEnv, PodNameEnv 환경 변수는 Downward API 로 전달해야 한다.
+// - Hash 는 충돌할 수 있으므로 MachineIDRegistry 와 함께 사용하는 것을 권장한다.
+func MachineIDFromPodIdentity() MachineIDFunc {
+	return func() (uint16, error) {
+		for _, name := range []string{PodUIDEnv, PodNameEnv} {
+			if value := os.Getenv(name); value != "" {
+				return hashMachineID(value), nil
+			}
+		}
+		hostname, err := os.Hostname()
+		if err != nil {
+			return 0, err
+		}
+		return hashMachineID(hostname), nil
+	}
+}
+
+// MachineIDFromHash - 지정한 문자열의 Hash 를 머신 ID 로 사용하는 제공 함수 반환
+func MachineIDFromHash(value string) MachineIDFunc {
+	return func() (uint16, error) {
+		if value == "" {
+			return 0, fmt.Errorf("%w: empty value", ErrMachineIDNotSet)
+		}
+		return hashMachineID(value), nil
+	}
+}
+
+// MachineIDFromPrivateIPv4 - 사설 IPv4 주소의 하위 16 비트를 머신 ID 로 사용하는 제공 함수 반환
+func MachineIDFromPrivateIPv4() MachineIDFunc {
+	return func() (uint16, error) {
+		ip, err := PrivateIPv4()
+		if err != nil {
+			return 0, fmt.Errorf("%w: %v", ErrMachineIDNotSet, err)
+		}
+		return uint16(ip[2])<<8 + uint16(ip[3]), nil
+	}
+}
+
+// MachineIDFromIPv4 - 첫번째 IPv4 주소의 하위 16 비트를 머신 ID 로 사용하는 제공 함수 반환
+func MachineIDFromIPv4() MachineIDFunc {
+	return func() (uint16, error) {
+		ip, err := IPv4()
+		if err != nil {
+			return 0, fmt.Errorf("%w: %v", ErrMachineIDNotSet, err)
+		}
+		return uint16(ip[2])<<8 + uint16(ip[3]), nil
+	}
+}
+
+// MachineIDFromIPv6 - 전역 IPv6 주소 전체 (128 비트) 의 Hash 를 머신 ID 로 사용하는 제공 함수 반환
+// conditions:
+// - SLAAC 등으로 하위 비트가 같은 주소가 여러 Prefix 에 존재할 수 있으므로 하위 16 비트가 아닌 전체 주소를 사용한다.
+// - Hash 는 충돌할 수 있으므로 MachineIDRegistry 와 함께 사용하는 것을 권장한다.
+func MachineIDFromIPv6() MachineIDFunc {
+	return func() (uint16, error) {
+		ip, err := IPv6()
+		if err != nil {
+			return 0, fmt.Errorf("%w: %v", ErrMachineIDNotSet, err)
+		}
+		return hashMachineID(string(ip.To16())), nil
+	}
+}
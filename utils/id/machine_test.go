@@ -0,0 +1,105 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package id
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+// ===== [ Private Functions ] =====
+
+// setEnv - 테스트 동안 환경 변수를 지정하고 종료 시 복원 (빈 값이면 제거)
+func setEnv(t *testing.T, name, value string) {
+	old, ok := os.LookupEnv(name)
+	if value == "" {
+		os.Unsetenv(name)
+	} else {
+		os.Setenv(name, value)
+	}
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(name, old)
+		} else {
+			os.Unsetenv(name)
+		}
+	})
+}
+
+// ===== [ Public Functions ] =====
+
+func TestChainMachineIDStopsOnInvalidEnv(t *testing.T) {
+	fallback := StaticMachineID(99)
+	for _, tc := range []struct {
+		value   string
+		id      uint16
+		invalid bool
+	}{
+		{value: "", id: 99},
+		{value: "12", id: 12},
+		{value: " 65535 ", id: 65535},
+		{value: "70000", invalid: true},
+		{value: "abc", invalid: true},
+	} {
+		setEnv(t, "TEST_MACHINE_ID", tc.value)
+		id, err := ChainMachineID(MachineIDFromEnv("TEST_MACHINE_ID"), fallback)()
+		if tc.invalid {
+			if !errors.Is(err, ErrInvalidMachineID) {
+				t.Errorf("value %q: returned (%d, %v), expected ErrInvalidMachineID", tc.value, id, err)
+			}
+			continue
+		}
+		if err != nil || id != tc.id {
+			t.Errorf("value %q: returned (%d, %v), expected %d", tc.value, id, err, tc.id)
+		}
+	}
+}
+
+func TestChainMachineIDNotSet(t *testing.T) {
+	_, err := ChainMachineID(MachineIDFromHash(""), MachineIDFromStatefulSetOrdinal("web", 0))()
+	if !errors.Is(err, ErrNoMachineID) {
+		t.Fatalf("returned %v, expected ErrNoMachineID", err)
+	}
+}
+
+func TestMachineIDFromStatefulSetOrdinal(t *testing.T) {
+	for _, tc := range []struct {
+		hostname string
+		offset   uint16
+		id       uint16
+		err      error
+	}{
+		{hostname: "web-3", offset: 10, id: 13},
+		{hostname: "web-0.web.default.svc.cluster.local", id: 0},
+		{hostname: "web", err: ErrMachineIDNotSet},
+		{hostname: "web-7d9f8-abcde", err: ErrMachineIDNotSet},
+		{hostname: "web-65535", offset: 1, err: ErrInvalidMachineID},
+	} {
+		id, err := MachineIDFromStatefulSetOrdinal(tc.hostname, tc.offset)()
+		if tc.err != nil {
+			if !errors.Is(err, tc.err) {
+				t.Errorf("%s: returned (%d, %v), expected %v", tc.hostname, id, err, tc.err)
+			}
+			continue
+		}
+		if err != nil || id != tc.id {
+			t.Errorf("%s: returned (%d, %v), expected %d", tc.hostname, id, err, tc.id)
+		}
+	}
+}
+
+func TestMemoryRegistryZeroValue(t *testing.T) {
+	var r MemoryRegistry
+	if err := r.Register(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Register(1); !errors.Is(err, ErrDuplicateMachineID) {
+		t.Fatalf("second Register returned %v, expected ErrDuplicateMachineID", err)
+	}
+	r.Release(1)
+	if err := r.Register(1); err != nil {
+		t.Fatalf("Register after Release returned %v", err)
+	}
+}
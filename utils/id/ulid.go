@@ -0,0 +1,209 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package id
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// ===== [ Constants and Variables ] =====
+const (
+	crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ" // ULID 에서 사용하는 Crockford Base32 문자
+	ulidLength        = 26                                 // ULID 문자열 길이
+)
+
+var (
+	ErrInvalidULID = errors.New("invalid ulid") // ULID 형식이 올바르지 않은 경우
+)
+
+var (
+	defaultULID     *ULIDGenerator
+	defaultULIDOnce sync.Once
+)
+
+// crockfordValues - Crockford Base32 문자별 값 (대소문자 구분 없음, 올바르지 않은 문자는 0xff)
+var crockfordValues = func() [256]byte {
+	var values [256]byte
+	for i := range values {
+		values[i] = 0xff
+	}
+	for i := 0; i < len(crockfordAlphabet); i++ {
+		c := crockfordAlphabet[i]
+		values[c] = byte(i)
+		if 'A' <= c && c <= 'Z' {
+			values[c+'a'-'A'] = byte(i)
+		}
+	}
+	return values
+}()
+
+// ========== [ ULID START ] =========
+
+// ULID - 48 bit 밀리초 시각과 80 bit 난수로 구성된 ULID 값
+type ULID [16]byte
+
+// String - Crockford Base32 26 자리 문자열 반환
+func (u ULID) String() string {
+	// 128 bit 앞에 2 bit 의 0 을 붙여 130 bit 를 5 bit 씩 인코딩
+	var buf [ulidLength]byte
+	for i := range buf {
+		var v byte
+		for b := 0; b < 5; b++ {
+			bit := i*5 + b - 2
+			if bit >= 0 && u[bit/8]>>(7-uint(bit%8))&1 == 1 {
+				v |= 1 << uint(4-b)
+			}
+		}
+		buf[i] = crockfordAlphabet[v]
+	}
+	return string(buf[:])
+}
+
+// Time - 생성 시각 (밀리초 단위) 반환
+func (u ULID) Time() time.Time {
+	return time.Unix(0, int64(u.Timestamp())*int64(time.Millisecond)).UTC()
+}
+
+// Timestamp - Unix 밀리초 시각 반환
+func (u ULID) Timestamp() uint64 {
+	return uint64(u[0])<<40 | uint64(u[1])<<32 | uint64(u[2])<<24 | uint64(u[3])<<16 | uint64(u[4])<<8 | uint64(u[5])
+}
+
+// IsZero - 비어있는 ULID 여부
+func (u ULID) IsZero() bool {
+	return u == ULID{}
+}
+
+// MarshalText - Crockford Base32 문자열로 변환
+func (u ULID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText - Crockford Base32 문자열을 ULID 로 변환
+func (u *ULID) UnmarshalText(text []byte) error {
+	parsed, err := ParseULID(string(text))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+// MarshalBinary - 16 바이트로 변환
+func (u ULID) MarshalBinary() ([]byte, error) {
+	return u[:], nil
+}
+
+// UnmarshalBinary - 16 바이트를 ULID 로 변환
+func (u *ULID) UnmarshalBinary(data []byte) error {
+	if len(data) != len(u) {
+		return fmt.Errorf("%w: %d bytes", ErrInvalidULID, len(data))
+	}
+	copy(u[:], data)
+	return nil
+}
+
+// ========== [ ULID END ] =========
+
+// ========== [ ULIDGenerator START ] =========
+
+// ULIDGenerator - 같은 밀리초 내에서도 순서가 보장되는 ULID 생성 정보 관리용
+// conditions:
+// - 동시 사용에 안전하다.
+// - 같은 밀리초 (또는 시각이 되돌아간 경우) 에는 이전 난수 값을 1 증가시키며, 80 bit 를 넘으면 ErrMonotonicOverflow
+type ULIDGenerator struct {
+	mu     sync.Mutex
+	opts   MonotonicOptions
+	lastMs uint64
+	last   [10]byte
+}
+
+// New - 다음 ULID 생성
+func (g *ULIDGenerator) New() (ULID, error) {
+	ms := g.opts.Clock().UnixNano() / int64(time.Millisecond)
+	if ms < 0 || ms >= 1<<48 {
+		return ULID{}, ErrInvalidTimestamp
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if uint64(ms) > g.lastMs {
+		if _, err := io.ReadFull(g.opts.Rand, g.last[:]); err != nil {
+			return ULID{}, err
+		}
+		g.lastMs = uint64(ms)
+	} else if !incrementBytes(g.last[:]) {
+		return ULID{}, ErrMonotonicOverflow
+	}
+
+	var u ULID
+	u[0], u[1], u[2] = byte(g.lastMs>>40), byte(g.lastMs>>32), byte(g.lastMs>>24)
+	u[3], u[4], u[5] = byte(g.lastMs>>16), byte(g.lastMs>>8), byte(g.lastMs)
+	copy(u[6:], g.last[:])
+	return u, nil
+}
+
+// ========== [ ULIDGenerator END ] =========
+
+// ===== [ Private Functions ] =====
+
+// incrementBytes - Big Endian 정수로 1 증가, 넘치면 false
+func incrementBytes(b []byte) bool {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ===== [ Public Functions ] =====
+
+// NewULIDGenerator - 지정한 옵션으로 ULIDGenerator 생성
+func NewULIDGenerator(opts MonotonicOptions) *ULIDGenerator {
+	opts.normalize()
+	return &ULIDGenerator{opts: opts}
+}
+
+// NewULID - 기본 ULIDGenerator 로 다음 ULID 생성
+func NewULID() (ULID, error) {
+	defaultULIDOnce.Do(func() {
+		defaultULID = NewULIDGenerator(MonotonicOptions{})
+	})
+	return defaultULID.New()
+}
+
+// ParseULID - Crockford Base32 26 자리 문자열을 ULID 로 변환
+// conditions:
+// - 대소문자를 구분하지 않으며, 128 bit 를 넘는 값 (첫 문자가 '7' 초과) 은 오류
+func ParseULID(s string) (ULID, error) {
+	var u ULID
+	if len(s) != ulidLength {
+		return ULID{}, fmt.Errorf("%w: %s", ErrInvalidULID, s)
+	}
+
+	for i := 0; i < ulidLength; i++ {
+		v := crockfordValues[s[i]]
+		if v == 0xff {
+			return ULID{}, fmt.Errorf("%w: %s", ErrInvalidULID, s)
+		}
+		for b := 0; b < 5; b++ {
+			if v>>uint(4-b)&1 == 0 {
+				continue
+			}
+			bit := i*5 + b - 2
+			if bit < 0 {
+				return ULID{}, fmt.Errorf("%w: overflow: %s", ErrInvalidULID, s)
+			}
+			u[bit/8] |= 1 << uint(7-bit%8)
+		}
+	}
+	return u, nil
+}
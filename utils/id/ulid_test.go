@@ -0,0 +1,159 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package id
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ===== [ Public Functions ] =====
+
+func TestULIDMonotonic(t *testing.T) {
+	base := time.Unix(1700000000, 0)
+	for _, tc := range []struct {
+		name    string
+		advance []time.Duration // 각 생성 전의 시각 이동
+		ms      []int64         // 기대하는 밀리초 (base 기준)
+		last    []byte          // 기대하는 난수 영역의 마지막 바이트
+	}{
+		{
+			name:    "same millisecond",
+			advance: []time.Duration{0, 0, 0},
+			ms:      []int64{0, 0, 0},
+			last:    []byte{0x10, 0x11, 0x12},
+		},
+		{
+			name:    "new millisecond rereads rand",
+			advance: []time.Duration{0, 0, time.Millisecond},
+			ms:      []int64{0, 0, 1},
+			last:    []byte{0x10, 0x11, 0x10},
+		},
+		{
+			name:    "clock backwards",
+			advance: []time.Duration{0, -time.Second, time.Millisecond},
+			ms:      []int64{0, 0, 0},
+			last:    []byte{0x10, 0x11, 0x12},
+		},
+	} {
+		clock := &fakeClock{now: base}
+		g := NewULIDGenerator(MonotonicOptions{Clock: clock.Now, Rand: constReader{0x10}})
+
+		var last ULID
+		for i, advance := range tc.advance {
+			clock.now = clock.now.Add(advance)
+			u, err := g.New()
+			if err != nil {
+				t.Fatalf("%s: %v", tc.name, err)
+			}
+			if i > 0 && (bytes.Compare(u[:], last[:]) <= 0 || u.String() <= last.String()) {
+				t.Fatalf("%s: %s is not greater than previous %s", tc.name, u, last)
+			}
+			if expected := base.Add(time.Duration(tc.ms[i]) * time.Millisecond); !u.Time().Equal(expected) {
+				t.Errorf("%s: step %d time %v, expected %v", tc.name, i, u.Time(), expected)
+			}
+			if u[15] != tc.last[i] {
+				t.Errorf("%s: step %d last byte %#x, expected %#x", tc.name, i, u[15], tc.last[i])
+			}
+			last = u
+		}
+	}
+}
+
+func TestULIDOverflow(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	g := NewULIDGenerator(MonotonicOptions{Clock: clock.Now, Rand: constReader{0xff}})
+
+	if _, err := g.New(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.New(); !errors.Is(err, ErrMonotonicOverflow) {
+		t.Fatalf("New in the same millisecond after max random returned %v, expected ErrMonotonicOverflow", err)
+	}
+
+	clock.now = clock.now.Add(time.Millisecond)
+	if _, err := g.New(); err != nil {
+		t.Fatalf("New in the next millisecond returned %v", err)
+	}
+}
+
+func TestULIDErrors(t *testing.T) {
+	g := NewULIDGenerator(MonotonicOptions{Clock: func() time.Time { return time.Unix(-1, 0) }, Rand: constReader{0}})
+	if _, err := g.New(); !errors.Is(err, ErrInvalidTimestamp) {
+		t.Errorf("New before epoch returned %v, expected ErrInvalidTimestamp", err)
+	}
+
+	g = NewULIDGenerator(MonotonicOptions{Rand: errReader{}})
+	if _, err := g.New(); err == nil {
+		t.Error("New with failing rand returned no error")
+	}
+}
+
+func TestParseULID(t *testing.T) {
+	for _, tc := range []struct {
+		s  string
+		ms uint64
+	}{
+		{"00000000000000000000000000", 0},
+		{"01ARZ3NDEKTSV4RRFFQ69G5FAV", 1469922850259},
+		{"01arz3ndektsv4rrffq69g5fav", 1469922850259},
+		{"7ZZZZZZZZZZZZZZZZZZZZZZZZZ", 1<<48 - 1},
+	} {
+		u, err := ParseULID(tc.s)
+		if err != nil {
+			t.Errorf("ParseULID(%q) returned %v", tc.s, err)
+			continue
+		}
+		if u.Timestamp() != tc.ms {
+			t.Errorf("ParseULID(%q) timestamp %d, expected %d", tc.s, u.Timestamp(), tc.ms)
+		}
+		if u.String() != strings.ToUpper(tc.s) {
+			t.Errorf("ParseULID(%q).String() = %s", tc.s, u.String())
+		}
+	}
+
+	for _, s := range []string{
+		"",
+		"01ARYZ6S41TSV4RRFFQ69G5FA",
+		"01ARZ3NDEKTSV4RRFFQ69G5FAVX",
+		"80000000000000000000000000", // 128 bit 초과
+		"ZZZZZZZZZZZZZZZZZZZZZZZZZZ",
+		"01ARYZ6S41TSV4RRFFQ69G5FAU", // Crockford 에 없는 문자
+		"01ARYZ6S41TSV4RRFFQ69G5FA-",
+	} {
+		if _, err := ParseULID(s); !errors.Is(err, ErrInvalidULID) {
+			t.Errorf("ParseULID(%q) returned %v, expected ErrInvalidULID", s, err)
+		}
+	}
+}
+
+func TestULIDRoundTrip(t *testing.T) {
+	u, err := NewULID()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := ParseULID(u.String())
+	if err != nil || parsed != u {
+		t.Fatalf("string round trip = (%s, %v), expected %s", parsed, err, u)
+	}
+
+	text, _ := u.MarshalText()
+	var fromText ULID
+	if err := fromText.UnmarshalText(text); err != nil || fromText != u {
+		t.Fatalf("text round trip = (%s, %v), expected %s", fromText, err, u)
+	}
+
+	data, _ := u.MarshalBinary()
+	var fromBinary ULID
+	if err := fromBinary.UnmarshalBinary(data); err != nil || fromBinary != u {
+		t.Fatalf("binary round trip = (%s, %v), expected %s", fromBinary, err, u)
+	}
+	if err := fromBinary.UnmarshalBinary(data[1:]); !errors.Is(err, ErrInvalidULID) {
+		t.Fatalf("UnmarshalBinary of 15 bytes returned %v, expected ErrInvalidULID", err)
+	}
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package id
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// ===== [ Constants and Variables ] =====
+const (
+	uuidCounterMask = 0x0fff // UUIDv7 rand_a (12 bit) 카운터 범위
+	uuidCounterSeed = 0x07ff // 새 밀리초의 카운터 시작값 범위 (증가할 여유를 남기기 위해 최상위 Bit 제외)
+)
+
+var (
+	ErrInvalidUUID       = errors.New("invalid uuid")                  // UUID 형식이 올바르지 않은 경우
+	ErrMonotonicOverflow = errors.New("monotonic counter overflow")    // 같은 밀리초 내의 순서를 더 이상 보장할 수 없는 경우
+	ErrInvalidTimestamp  = errors.New("timestamp out of range for id") // 시각을 ID 에 담을 수 없는 경우
+)
+
+var (
+	defaultUUIDv7     *UUIDv7Generator
+	defaultUUIDv7Once sync.Once
+)
+
+// ========== [ UUID START ] =========
+
+// UUID - RFC 9562 UUID 값
+type UUID [16]byte
+
+// String - 표준 형식 (`xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx`) 문자열 반환
+func (u UUID) String() string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+// Version - UUID 버전 반환
+func (u UUID) Version() int {
+	return int(u[6] >> 4)
+}
+
+// IsRFC9562 - RFC 9562 Variant (`10xx`) 여부
+func (u UUID) IsRFC9562() bool {
+	return u[8]&0xc0 == 0x80
+}
+
+// IsZero - Nil UUID 여부
+func (u UUID) IsZero() bool {
+	return u == UUID{}
+}
+
+// Time - UUIDv7 의 생성 시각 (밀리초 단위) 반환
+// conditions:
+// - UUIDv7 이 아닌 경우는 Zero Time 반환
+func (u UUID) Time() time.Time {
+	if u.Version() != 7 {
+		return time.Time{}
+	}
+	ms := int64(u[0])<<40 | int64(u[1])<<32 | int64(u[2])<<24 | int64(u[3])<<16 | int64(u[4])<<8 | int64(u[5])
+	return time.Unix(0, ms*int64(time.Millisecond)).UTC()
+}
+
+// MarshalText - 표준 형식 문자열로 변환
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText - ParseUUID 로 지원하는 형식의 문자열을 UUID 로 변환
+func (u *UUID) UnmarshalText(text []byte) error {
+	parsed, err := ParseUUID(string(text))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+// MarshalBinary - 16 바이트로 변환
+func (u UUID) MarshalBinary() ([]byte, error) {
+	return u[:], nil
+}
+
+// UnmarshalBinary - 16 바이트를 UUID 로 변환
+func (u *UUID) UnmarshalBinary(data []byte) error {
+	if len(data) != len(u) {
+		return fmt.Errorf("%w: %d bytes", ErrInvalidUUID, len(data))
+	}
+	copy(u[:], data)
+	return nil
+}
+
+// ========== [ UUID END ] =========
+
+// ========== [ UUIDv7Generator START ] =========
+
+// MonotonicOptions - 시각 기반 정렬 가능 ID (UUIDv7, ULID) 생성 옵션
+type MonotonicOptions struct {
+	Clock func() time.Time // 현재 시각 (nil 이면 time.Now)
+	Rand  io.Reader        // 난수 생성기 (nil 이면 crypto/rand.Reader)
+}
+
+// normalize - 기본값 적용
+func (o *MonotonicOptions) normalize() {
+	if o.Clock == nil {
+		o.Clock = time.Now
+	}
+	if o.Rand == nil {
+		o.Rand = rand.Reader
+	}
+}
+
+// UUIDv7Generator - 같은 밀리초 내에서도 순서가 보장되는 UUIDv7 생성 정보 관리용
+// conditions:
+// - 동시 사용에 안전하다.
+// - RFC 9562 Method 1 과 같이 rand_a (12 bit) 를 카운터로 사용하며, 카운터가 소진되면 시각을 1 밀리초 앞당긴다.
+// - 시각이 되돌아간 경우는 마지막 시각을 계속 사용한다.
+type UUIDv7Generator struct {
+	mu      sync.Mutex
+	opts    MonotonicOptions
+	lastMs  int64
+	counter uint16
+}
+
+// New - 다음 UUIDv7 생성
+func (g *UUIDv7Generator) New() (UUID, error) {
+	var u UUID
+	if _, err := io.ReadFull(g.opts.Rand, u[6:]); err != nil {
+		return UUID{}, err
+	}
+
+	ms := g.opts.Clock().UnixNano() / int64(time.Millisecond)
+	if ms < 0 || ms >= 1<<48 {
+		return UUID{}, ErrInvalidTimestamp
+	}
+
+	g.mu.Lock()
+	if ms > g.lastMs {
+		g.lastMs = ms
+		g.counter = (uint16(u[6])<<8 | uint16(u[7])) & uuidCounterSeed
+	} else {
+		g.counter++
+		if g.counter > uuidCounterMask {
+			g.lastMs++
+			g.counter = (uint16(u[6])<<8 | uint16(u[7])) & uuidCounterSeed
+		}
+	}
+	ms, counter := g.lastMs, g.counter
+	g.mu.Unlock()
+
+	// 카운터 소진으로 앞당긴 시각이 범위를 넘는 경우
+	if ms >= 1<<48 {
+		return UUID{}, ErrInvalidTimestamp
+	}
+	u[0], u[1], u[2] = byte(ms>>40), byte(ms>>32), byte(ms>>24)
+	u[3], u[4], u[5] = byte(ms>>16), byte(ms>>8), byte(ms)
+	u[6] = 0x70 | byte(counter>>8)
+	u[7] = byte(counter)
+	u[8] = 0x80 | u[8]&0x3f
+	return u, nil
+}
+
+// ========== [ UUIDv7Generator END ] =========
+
+// ===== [ Private Functions ] =====
+
+// hexValue - Hex 문자 값, 올바르지 않으면 false
+func hexValue(c byte) (byte, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0', true
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10, true
+	case 'A' <= c && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
+}
+
+// ===== [ Public Functions ] =====
+
+// NewUUIDv7Generator - 지정한 옵션으로 UUIDv7Generator 생성
+func NewUUIDv7Generator(opts MonotonicOptions) *UUIDv7Generator {
+	opts.normalize()
+	return &UUIDv7Generator{opts: opts}
+}
+
+// NewUUIDv7 - 기본 UUIDv7Generator 로 다음 UUIDv7 생성
+func NewUUIDv7() (UUID, error) {
+	defaultUUIDv7Once.Do(func() {
+		defaultUUIDv7 = NewUUIDv7Generator(MonotonicOptions{})
+	})
+	return defaultUUIDv7.New()
+}
+
+// ParseUUID - 표준 형식 (`xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx`), `urn:uuid:` 접두어, `{}` 로 감싼 형식, 32 자리 Hex 문자열을 UUID 로 변환
+// conditions:
+// - 대소문자를 구분하지 않으며, 버전은 검증하지 않는다.
+func ParseUUID(s string) (UUID, error) {
+	var u UUID
+	str := s
+	switch {
+	case len(str) == 45 && str[:9] == "urn:uuid:":
+		str = str[9:]
+	case len(str) == 38 && str[0] == '{' && str[37] == '}':
+		str = str[1:37]
+	}
+
+	switch len(str) {
+	case 36:
+		if str[8] != '-' || str[13] != '-' || str[18] != '-' || str[23] != '-' {
+			return UUID{}, fmt.Errorf("%w: %s", ErrInvalidUUID, s)
+		}
+		str = str[0:8] + str[9:13] + str[14:18] + str[19:23] + str[24:]
+	case 32:
+	default:
+		return UUID{}, fmt.Errorf("%w: %s", ErrInvalidUUID, s)
+	}
+
+	for i := range u {
+		hi, ok1 := hexValue(str[2*i])
+		lo, ok2 := hexValue(str[2*i+1])
+		if !ok1 || !ok2 {
+			return UUID{}, fmt.Errorf("%w: %s", ErrInvalidUUID, s)
+		}
+		u[i] = hi<<4 | lo
+	}
+	return u, nil
+}
+
+// ParseUUIDv7 - ParseUUID 로 변환하고 RFC 9562 UUIDv7 인지 검증
+func ParseUUIDv7(s string) (UUID, error) {
+	u, err := ParseUUID(s)
+	if err != nil {
+		return UUID{}, err
+	}
+	if u.Version() != 7 || !u.IsRFC9562() {
+		return UUID{}, fmt.Errorf("%w: not a version 7 uuid: %s", ErrInvalidUUID, s)
+	}
+	return u, nil
+}
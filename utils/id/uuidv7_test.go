@@ -0,0 +1,239 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package id
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+// ===== [ Types ] =====
+type (
+	// constReader - 모든 바이트를 지정한 값으로 채우는 테스트용 난수 생성기
+	constReader struct {
+		b byte
+	}
+
+	// errReader - 항상 오류를 반환하는 테스트용 난수 생성기
+	errReader struct{}
+)
+
+// ===== [ Implementations ] =====
+
+// Read - 지정한 값으로 채우기
+func (r constReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = r.b
+	}
+	return len(p), nil
+}
+
+// Read - 오류 반환
+func (errReader) Read(p []byte) (int, error) {
+	return 0, errors.New("rand failed")
+}
+
+// ===== [ Private Functions ] =====
+
+// uuidCounter - UUIDv7 의 rand_a (12 bit) 카운터 반환
+func uuidCounter(u UUID) uint16 {
+	return uint16(u[6]&0x0f)<<8 | uint16(u[7])
+}
+
+// ===== [ Public Functions ] =====
+
+func TestUUIDv7Monotonic(t *testing.T) {
+	base := time.Unix(1700000000, 0)
+	for _, tc := range []struct {
+		name    string
+		rand    byte
+		seed    uint16
+		advance []time.Duration // 각 생성 전의 시각 이동
+		ms      []int64         // 기대하는 밀리초 (base 기준)
+		counter []uint16        // 기대하는 카운터
+	}{
+		{
+			name:    "same millisecond",
+			rand:    0x00,
+			advance: []time.Duration{0, 0, 0},
+			ms:      []int64{0, 0, 0},
+			counter: []uint16{0, 1, 2},
+		},
+		{
+			name:    "seed keeps headroom",
+			rand:    0xff,
+			advance: []time.Duration{0, 0},
+			ms:      []int64{0, 0},
+			counter: []uint16{uuidCounterSeed, uuidCounterSeed + 1},
+		},
+		{
+			name:    "new millisecond reseeds",
+			rand:    0x00,
+			advance: []time.Duration{0, 0, time.Millisecond},
+			ms:      []int64{0, 0, 1},
+			counter: []uint16{0, 1, 0},
+		},
+		{
+			name:    "clock backwards",
+			rand:    0x00,
+			advance: []time.Duration{0, -time.Second, time.Millisecond},
+			ms:      []int64{0, 0, 0},
+			counter: []uint16{0, 1, 2},
+		},
+	} {
+		clock := &fakeClock{now: base}
+		g := NewUUIDv7Generator(MonotonicOptions{Clock: clock.Now, Rand: constReader{tc.rand}})
+
+		var last UUID
+		for i, advance := range tc.advance {
+			clock.now = clock.now.Add(advance)
+			u, err := g.New()
+			if err != nil {
+				t.Fatalf("%s: %v", tc.name, err)
+			}
+			if u.Version() != 7 || !u.IsRFC9562() {
+				t.Fatalf("%s: %s is not a RFC 9562 version 7 uuid", tc.name, u)
+			}
+			if i > 0 && bytes.Compare(u[:], last[:]) <= 0 {
+				t.Fatalf("%s: %s is not greater than previous %s", tc.name, u, last)
+			}
+			if expected := base.Add(time.Duration(tc.ms[i]) * time.Millisecond); !u.Time().Equal(expected) {
+				t.Errorf("%s: step %d time %v, expected %v", tc.name, i, u.Time(), expected)
+			}
+			if uuidCounter(u) != tc.counter[i] {
+				t.Errorf("%s: step %d counter %#x, expected %#x", tc.name, i, uuidCounter(u), tc.counter[i])
+			}
+			last = u
+		}
+	}
+}
+
+func TestUUIDv7CounterOverflow(t *testing.T) {
+	base := time.Unix(1700000000, 0)
+	clock := &fakeClock{now: base}
+	g := NewUUIDv7Generator(MonotonicOptions{Clock: clock.Now, Rand: constReader{0xff}})
+
+	// 카운터가 0x07ff 에서 시작하므로 0x0fff 까지 0x0801 개를 생성할 수 있다.
+	var last UUID
+	for i := 0; i <= uuidCounterMask-uuidCounterSeed; i++ {
+		u, err := g.New()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i > 0 && bytes.Compare(u[:], last[:]) <= 0 {
+			t.Fatalf("%s is not greater than previous %s", u, last)
+		}
+		last = u
+	}
+	if uuidCounter(last) != uuidCounterMask || !last.Time().Equal(base) {
+		t.Fatalf("last uuid before overflow has counter %#x, time %v", uuidCounter(last), last.Time())
+	}
+
+	// 카운터가 넘치면 시각을 1 밀리초 앞당기고 카운터를 다시 시작
+	u, err := g.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Compare(u[:], last[:]) <= 0 {
+		t.Fatalf("%s after overflow is not greater than previous %s", u, last)
+	}
+	if !u.Time().Equal(base.Add(time.Millisecond)) || uuidCounter(u) != uuidCounterSeed {
+		t.Fatalf("uuid after overflow has counter %#x, time %v", uuidCounter(u), u.Time())
+	}
+
+	// 실제 시각이 앞당긴 시각에 도달해도 순서 유지
+	clock.now = base.Add(time.Millisecond)
+	next, err := g.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Compare(next[:], u[:]) <= 0 {
+		t.Fatalf("%s is not greater than previous %s", next, u)
+	}
+}
+
+func TestUUIDv7Errors(t *testing.T) {
+	g := NewUUIDv7Generator(MonotonicOptions{Clock: func() time.Time { return time.Unix(-1, 0) }, Rand: constReader{0}})
+	if _, err := g.New(); !errors.Is(err, ErrInvalidTimestamp) {
+		t.Errorf("New before epoch returned %v, expected ErrInvalidTimestamp", err)
+	}
+
+	g = NewUUIDv7Generator(MonotonicOptions{Rand: errReader{}})
+	if _, err := g.New(); err == nil {
+		t.Error("New with failing rand returned no error")
+	}
+}
+
+func TestParseUUID(t *testing.T) {
+	const canonical = "0189f7e2-3c4d-7a1b-8c2d-0123456789ab"
+	expected, err := ParseUUID(canonical)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected.String() != canonical {
+		t.Fatalf("String = %s, expected %s", expected.String(), canonical)
+	}
+
+	for _, s := range []string{
+		"0189F7E2-3C4D-7A1B-8C2D-0123456789AB",
+		"urn:uuid:" + canonical,
+		"{" + canonical + "}",
+		"0189f7e23c4d7a1b8c2d0123456789ab",
+	} {
+		u, err := ParseUUIDv7(s)
+		if err != nil || u != expected {
+			t.Errorf("ParseUUIDv7(%q) = (%s, %v), expected %s", s, u, err, expected)
+		}
+	}
+
+	for _, s := range []string{
+		"",
+		"0189f7e2-3c4d-7a1b-8c2d-0123456789a",
+		"0189f7e2_3c4d-7a1b-8c2d-0123456789ab",
+		"0189f7e2-3c4d-7a1b-8c2d-0123456789ag",
+		"{0189f7e2-3c4d-7a1b-8c2d-0123456789ab",
+		"urn:uid:0189f7e2-3c4d-7a1b-8c2d-0123456789ab",
+	} {
+		if _, err := ParseUUID(s); !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("ParseUUID(%q) returned %v, expected ErrInvalidUUID", s, err)
+		}
+	}
+
+	for _, s := range []string{
+		"0189f7e2-3c4d-4a1b-8c2d-0123456789ab", // version 4
+		"0189f7e2-3c4d-7a1b-cc2d-0123456789ab", // variant 110x
+		"0189f7e2-3c4d-7a1b-0c2d-0123456789ab", // variant 0xxx
+	} {
+		if _, err := ParseUUID(s); err != nil {
+			t.Errorf("ParseUUID(%q) returned %v", s, err)
+		}
+		if _, err := ParseUUIDv7(s); !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("ParseUUIDv7(%q) returned %v, expected ErrInvalidUUID", s, err)
+		}
+	}
+}
+
+func TestUUIDRoundTrip(t *testing.T) {
+	u, err := NewUUIDv7()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text, _ := u.MarshalText()
+	var fromText UUID
+	if err := fromText.UnmarshalText(text); err != nil || fromText != u {
+		t.Fatalf("text round trip = (%s, %v), expected %s", fromText, err, u)
+	}
+
+	data, _ := u.MarshalBinary()
+	var fromBinary UUID
+	if err := fromBinary.UnmarshalBinary(data); err != nil || fromBinary != u {
+		t.Fatalf("binary round trip = (%s, %v), expected %s", fromBinary, err, u)
+	}
+	if err := fromBinary.UnmarshalBinary(data[1:]); !errors.Is(err, ErrInvalidUUID) {
+		t.Fatalf("UnmarshalBinary of 15 bytes returned %v, expected ErrInvalidUUID", err)
+	}
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package reflect
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// ===== [ Constants and Variables ] =====
+const ()
+
+var timeType = reflect.TypeOf(time.Time{}) // time.Time 형식
+
+// ========== [ Hash START ] =========
+
+// hasher - 구조적 Hash 계산을 위한 정보 관리용
+// conditions:
+// - Equal (cmp.equals) 이 비교하는 정보만 Hash 에 반영해야 Equal 이 같다고 판단한 값들의 Hash 가 항상 같다.
+type hasher struct {
+	h           hash.Hash // Hash 출력 대상
+	floatFormat string    // 실수 형의 포맷 정보
+}
+
+// hash - 지정한 값을 지정한 Depth 기준으로 Hash 에 반영
+// conditions:
+// - cmp.equals 와 동일한 순서와 규칙으로 처리한다.
+func (s *hasher) hash(v reflect.Value, level int) {
+	// check depth, Equal 은 최대 Depth 이후를 비교하지 않는다.
+	if MaxDepth > 0 && level > MaxDepth {
+		s.writeString("<max depth>")
+		return
+	}
+
+	// check value is nil
+	if !v.IsValid() {
+		s.writeString("<nil>")
+		return
+	}
+
+	vType := v.Type()
+	vKind := v.Kind()
+	s.writeString(vType.String())
+
+	// pointer, interface 인 경우 참조해제
+	if vKind == reflect.Ptr || vKind == reflect.Interface {
+		s.hash(v.Elem(), level+1)
+		return
+	}
+
+	switch vKind {
+	// 구조체 재귀처리
+	case reflect.Struct:
+		// Equal 함수를 지원하는 경우는 Equal 함수의 비교 기준을 알 수 없으므로 형식만 반영 (time.Time 은 시각 기준)
+		if eqFunc := v.MethodByName("Equal"); eqFunc.IsValid() && eqFunc.CanInterface() {
+			funcType := eqFunc.Type()
+			if funcType.NumIn() == 1 && funcType.In(0) == vType {
+				if vType == timeType {
+					t := v.Interface().(time.Time)
+					s.writeUint(uint64(t.Unix()))
+					s.writeUint(uint64(t.Nanosecond()))
+				}
+				return
+			}
+		}
+
+		for i := 0; i < v.NumField(); i++ {
+			if vType.Field(i).PkgPath != "" && !CompareUnexportedFields {
+				continue // 예상치 못한 필드는 생략. ex. s in t struct {s string}
+			}
+
+			if vType.Field(i).Tag.Get("deep") == "-" {
+				continue // ignore
+			}
+
+			s.writeString(vType.Field(i).Name)
+			s.hash(v.Field(i), level+1)
+		}
+		// 맵 처리, 키 순서와 무관하도록 키/값 별 Hash 를 정렬해서 반영
+	case reflect.Map:
+		if v.IsNil() {
+			s.writeString("<nil map>")
+			return
+		}
+
+		entries := make([][]byte, 0, v.Len())
+		for _, key := range v.MapKeys() {
+			sub := &hasher{h: sha256.New(), floatFormat: s.floatFormat}
+			sub.hash(key, level+1)
+			sub.hash(v.MapIndex(key), level+1)
+			entries = append(entries, sub.h.Sum(nil))
+		}
+		sort.Slice(entries, func(i, j int) bool {
+			return bytes.Compare(entries[i], entries[j]) < 0
+		})
+
+		s.writeUint(uint64(len(entries)))
+		for _, entry := range entries {
+			s.h.Write(entry)
+		}
+		// Array
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			s.hash(v.Index(i), level+1)
+		}
+		// Slice
+	case reflect.Slice:
+		if v.IsNil() {
+			s.writeString("<nil slice>")
+			return
+		}
+
+		s.writeUint(uint64(v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			s.hash(v.Index(i), level+1)
+		}
+		// Float, Equal 과 동일하게 FloatPrecision 자리로 반올림
+	case reflect.Float32, reflect.Float64:
+		s.writeString(fmt.Sprintf(s.floatFormat, v.Float()))
+		// Boolean
+	case reflect.Bool:
+		if v.Bool() {
+			s.writeUint(1)
+		} else {
+			s.writeUint(0)
+		}
+		// Int
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		s.writeUint(uint64(v.Int()))
+		// Uint
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		s.writeUint(v.Uint())
+		// String
+	case reflect.String:
+		s.writeString(v.String())
+
+	default:
+		// Equal 이 비교하지 못하는 형식은 같은 것으로 판단하므로 형식만 반영
+		logError(ErrNotHandled)
+	}
+}
+
+// writeUint - 지정한 값을 고정 길이로 반영
+func (s *hasher) writeUint(v uint64) {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], v)
+	s.h.Write(buf[:])
+}
+
+// writeString - 지정한 문자열을 길이와 함께 반영
+func (s *hasher) writeString(str string) {
+	s.writeUint(uint64(len(str)))
+	s.h.Write([]byte(str))
+}
+
+// ========== [ Hash END ] =========
+
+// ===== [ Private Functions ] =====
+// ===== [ Public Functions ] =====
+
+// Hash - 지정한 값의 구조적 Hash (SHA-256) 를 Hex 문자열로 반환
+// conditions:
+// - Equal 과 동일한 규칙을 적용하므로 Equal 이 같다고 판단한 값들은 항상 같은 Hash 를 가진다.
+// - struct 형식인 경우에 `deep:"-"` 태그가 존재하는 필드는 생략하며, CompareUnexportedFields 설정을 따른다.
+// - 실수는 FloatPrecision 자리로 반올림하고, 맵은 키 순서와 무관하게 계산한다.
+// - 해당 형식에 `Equal` 함수가 존재하면 (time.Time 제외) 형식만 반영하므로 값이 달라도 같은 Hash 를 가질 수 있다.
+func Hash(v interface{}) string {
+	s := &hasher{
+		h:           sha256.New(),
+		floatFormat: fmt.Sprintf("%%.%df", FloatPrecision),
+	}
+	s.hash(reflect.ValueOf(v), 0)
+
+	return hex.EncodeToString(s.h.Sum(nil))
+}
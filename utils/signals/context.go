@@ -0,0 +1,53 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package signals
+
+import (
+	"context"
+	"os"
+)
+
+// ===== [ Constants and Variables ] =====
+const ()
+
+var ()
+
+// ===== [ Types ] =====
+type ()
+
+// ===== [ Implementations ] =====
+// ===== [ Private Functions ] =====
+// ===== [ Public Functions ] =====
+
+// NotifyContext - 지정한 시그널이 수신되면 취소되는 Context 반환
+// conditions:
+// - SetupSignalHandler 와 달리 여러 번 호출할 수 있다.
+// - src 가 nil 이면 OSSource, sigs 가 없으면 종료 시그널 (SIGTERM/SIGINT) 사용
+// - 반환된 cancel 을 호출하면 시그널 수신 등록이 해제되므로 반드시 호출해야 한다.
+func NotifyContext(parent context.Context, src Source, sigs ...os.Signal) (context.Context, context.CancelFunc) {
+	src = sourceOrDefault(src)
+	if len(sigs) == 0 {
+		sigs = shutdownSignals
+	}
+
+	ctx, cancel := context.WithCancel(parent)
+	c := make(chan os.Signal, 1)
+	src.Notify(c, sigs...)
+
+	// 부모 Context 가 이미 종료된 경우는 대기하지 않는다.
+	if ctx.Err() == nil {
+		go func() {
+			select {
+			case <-c:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+	}
+
+	return ctx, func() {
+		cancel()
+		src.Stop(c)
+	}
+}
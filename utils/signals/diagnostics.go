@@ -0,0 +1,248 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package signals
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"strconv"
+	"sync"
+	"time"
+
+	"k8s.io/klog"
+)
+
+// ===== [ Constants and Variables ] =====
+const (
+	DefaultCPUProfileDuration = 10 * time.Second // CPU 프로파일 기본 수집 시간
+	DefaultDebugVerbosity     = 4                // 로그 레벨 전환 시 기본 디버그 레벨
+
+	diagTimeLayout = "20060102T150405.000Z0700"
+)
+
+var (
+	ErrVerbosityFlagNotFound = errors.New("klog verbosity flag not found") // klog 의 "v" 플래그를 찾을 수 없는 경우
+)
+
+var (
+	klogFlagsOnce sync.Once
+	klogFlags     *flag.FlagSet // flag.CommandLine 에 klog 플래그가 없는 경우 사용할 플래그
+)
+
+// ===== [ Types ] =====
+type (
+	// DiagnosticsOptions - 진단용 시그널 처리기 구성 옵션
+	// conditions:
+	// - 모든 기능은 기본적으로 비활성화 상태이다.
+	DiagnosticsOptions struct {
+		EnableDump         bool          // SIGUSR1 수신 시 진단 정보 출력 여부
+		DumpDir            string        // 진단 정보 출력 경로 (빈 문자열이면 os.TempDir())
+		CPUProfileDuration time.Duration // CPU 프로파일 수집 시간 (0 이면 DefaultCPUProfileDuration, 음수면 수집하지 않음)
+
+		EnableVerbosityToggle bool       // SIGUSR2 수신 시 klog 로그 레벨 전환 여부
+		DebugVerbosity        int        // 전환할 디버그 레벨 (0 이면 DefaultDebugVerbosity)
+		Verbosity             flag.Value // klog "v" 플래그 (nil 이면 flag.CommandLine 에서 검색)
+
+		Source Source // 시그널 Source (nil 이면 OSSource)
+	}
+
+	// verbosityToggle - 설정된 로그 레벨과 디버그 레벨 간의 전환 관리
+	verbosityToggle struct {
+		flag       flag.Value
+		configured string
+		debug      string
+		debugOn    bool
+	}
+)
+
+// ===== [ Implementations ] =====
+
+// toggle - 로그 레벨을 전환하고 적용된 레벨 반환
+func (t *verbosityToggle) toggle() (string, error) {
+	level := t.debug
+	if t.debugOn {
+		level = t.configured
+	}
+
+	if err := t.flag.Set(level); err != nil {
+		return "", err
+	}
+	t.debugOn = !t.debugOn
+	return level, nil
+}
+
+// ===== [ Private Functions ] =====
+
+// verbosityFlag - klog 의 "v" 플래그 검색
+// conditions:
+// - flag.CommandLine 에 등록되지 않은 경우는 별도 FlagSet 에 klog 플래그를 등록해서 사용한다.
+func verbosityFlag() (flag.Value, error) {
+	if f := flag.CommandLine.Lookup("v"); f != nil {
+		return f.Value, nil
+	}
+
+	klogFlagsOnce.Do(func() {
+		klogFlags = flag.NewFlagSet("klog", flag.ContinueOnError)
+		klog.InitFlags(klogFlags)
+	})
+	if f := klogFlags.Lookup("v"); f != nil {
+		return f.Value, nil
+	}
+	return nil, ErrVerbosityFlagNotFound
+}
+
+// writeDiagFile - 지정한 경로에 파일을 생성하고 지정한 함수로 내용 출력
+func writeDiagFile(path string, write func(f *os.File) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ===== [ Public Functions ] =====
+
+// DumpDiagnostics - 고루틴 스택, 힙/CPU 프로파일, runtime.MemStats 를 시각 정보가 포함된 파일로 출력하고 생성된 파일 경로들 반환
+// conditions:
+// - 파일명 형식은 `diag-<timestamp>-<kind>.<ext>`
+// - cpuDuration 이 0 이하면 CPU 프로파일은 수집하지 않는다.
+// - 일부 항목이 실패해도 나머지 항목은 계속 출력하며, 첫번째 오류를 반환한다.
+func DumpDiagnostics(dir string, cpuDuration time.Duration) ([]string, error) {
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	prefix := filepath.Join(dir, "diag-"+time.Now().UTC().Format(diagTimeLayout))
+	var files []string
+	var firstErr error
+	record := func(path string, err error) {
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("dump %s: %w", filepath.Base(path), err)
+			}
+			return
+		}
+		files = append(files, path)
+	}
+
+	// 고루틴 스택
+	path := prefix + "-goroutines.txt"
+	record(path, writeDiagFile(path, func(f *os.File) error {
+		return pprof.Lookup("goroutine").WriteTo(f, 2)
+	}))
+
+	// 힙 프로파일
+	path = prefix + "-heap.pprof"
+	record(path, writeDiagFile(path, func(f *os.File) error {
+		runtime.GC()
+		return pprof.WriteHeapProfile(f)
+	}))
+
+	// runtime.MemStats
+	path = prefix + "-memstats.json"
+	record(path, writeDiagFile(path, func(f *os.File) error {
+		var ms runtime.MemStats
+		runtime.ReadMemStats(&ms)
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		return enc.Encode(ms)
+	}))
+
+	// CPU 프로파일
+	if cpuDuration > 0 {
+		path = prefix + "-cpu.pprof"
+		record(path, writeDiagFile(path, func(f *os.File) error {
+			if err := pprof.StartCPUProfile(f); err != nil {
+				return err
+			}
+			time.Sleep(cpuDuration)
+			pprof.StopCPUProfile()
+			return nil
+		}))
+	}
+
+	return files, firstErr
+}
+
+// SetupDiagnostics - 옵션에 따라 진단용 시그널 처리기 등록
+// conditions:
+// - SIGUSR1: 진단 정보를 DumpDir 에 출력
+// - SIGUSR2: klog 로그 레벨을 설정된 레벨과 디버그 레벨 간에 전환
+// - 지정한 Context 가 종료되면 시그널 수신 등록을 해제한다.
+// - 해당 시그널을 지원하지 않는 환경 (Windows) 에서는 아무 것도 하지 않는다.
+func SetupDiagnostics(ctx context.Context, opts DiagnosticsOptions) error {
+	src := sourceOrDefault(opts.Source)
+	if opts.CPUProfileDuration == 0 {
+		opts.CPUProfileDuration = DefaultCPUProfileDuration
+	}
+	if opts.DebugVerbosity == 0 {
+		opts.DebugVerbosity = DefaultDebugVerbosity
+	}
+
+	dumpCh := make(chan os.Signal, 1)
+	if opts.EnableDump && len(dumpSignals) > 0 {
+		src.Notify(dumpCh, dumpSignals...)
+	}
+
+	var toggle *verbosityToggle
+	verbosityCh := make(chan os.Signal, 1)
+	if opts.EnableVerbosityToggle && len(verbositySignals) > 0 {
+		v := opts.Verbosity
+		if v == nil {
+			var err error
+			if v, err = verbosityFlag(); err != nil {
+				src.Stop(dumpCh)
+				return err
+			}
+		}
+		toggle = &verbosityToggle{
+			flag:       v,
+			configured: v.String(),
+			debug:      strconv.Itoa(opts.DebugVerbosity),
+		}
+		src.Notify(verbosityCh, verbositySignals...)
+	}
+
+	go func() {
+		defer src.Stop(dumpCh)
+		defer src.Stop(verbosityCh)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-dumpCh:
+				files, err := DumpDiagnostics(opts.DumpDir, opts.CPUProfileDuration)
+				if err != nil {
+					klog.Errorf("dump diagnostics failed, err=%v", err)
+				}
+				klog.Infof("diagnostics dumped, files=%v", files)
+			case <-verbosityCh:
+				level, err := toggle.toggle()
+				if err != nil {
+					klog.Errorf("toggle verbosity failed, err=%v", err)
+					continue
+				}
+				klog.Infof("verbosity changed, level=%s", level)
+			}
+		}
+	}()
+
+	return nil
+}
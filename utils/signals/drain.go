@@ -0,0 +1,173 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package signals
+
+import (
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/klog"
+)
+
+// ===== [ Constants and Variables ] =====
+const (
+	DefaultPropagationDelay = 5 * time.Second // Endpoint 제거가 전파될 때까지의 기본 대기 시간
+
+	ReadyzPath = "/readyz" // Readiness Probe 경로
+	LivezPath  = "/livez"  // Liveness Probe 경로
+)
+
+var ()
+
+// ===== [ Types ] =====
+type (
+	// DrainOptions - Drainer 구성 옵션
+	DrainOptions struct {
+		PropagationDelay time.Duration // Readiness 실패 전환 후 Stop 채널을 닫기까지의 대기 시간 (0 이면 DefaultPropagationDelay, 음수면 대기하지 않음)
+		Source           Source        // 시그널 Source (nil 이면 OSSource)
+	}
+
+	// Drainer - 종료 시그널 수신 시 Readiness 를 실패로 전환하고 Endpoint 제거가 전파된 이후에 Stop 채널을 닫는 관리자
+	// conditions:
+	// - Kubernetes 의 Endpoints Controller 가 Pod 을 제거하기 전에 요청 처리가 중단되는 것을 방지한다.
+	Drainer struct {
+		opts      DrainOptions
+		draining  int32
+		stop      chan struct{}
+		drainOnce sync.Once
+		stopOnce  sync.Once
+		startOnce sync.Once
+	}
+)
+
+// ===== [ Implementations ] =====
+
+// Start - 종료 시그널 (SIGTERM/SIGINT) 수신 대기를 시작하고 Stop 채널 반환
+// conditions:
+// - 첫번째 시그널에서 Drain 을 시작하고, 대기 시간 중 두번째 시그널이 수신되면 즉시 Stop 채널을 닫는다.
+// - 반환된 채널을 ShutdownManager.Run 에 전달하면 대기 시간 이후에 종료 Hook 들이 실행된다.
+// - 여러 번 호출해도 시그널 수신 등록은 한번만 수행된다.
+func (d *Drainer) Start() <-chan struct{} {
+	d.startOnce.Do(func() {
+		src := sourceOrDefault(d.opts.Source)
+		c := make(chan os.Signal, 2)
+		src.Notify(c, shutdownSignals...)
+
+		go func() {
+			defer src.Stop(c)
+
+			select {
+			case sig := <-c:
+				klog.Infof("shutdown signal received, start draining, signal=%v, delay=%v", sig, d.opts.PropagationDelay)
+				go d.Drain()
+			case <-d.stop:
+				return
+			}
+
+			select {
+			case <-c:
+				d.closeStop() // second signal. Stop directly.
+			case <-d.stop:
+			}
+		}()
+	})
+
+	return d.stop
+}
+
+// Drain - Readiness 를 실패로 전환하고 대기 시간 이후에 Stop 채널을 닫는다.
+// conditions:
+// - Pod preStop Hook 등에서 시그널 없이 직접 호출할 수 있으며, Stop 채널이 닫힐 때까지 대기한다.
+// - 여러 번 호출해도 Drain 은 한번만 수행된다.
+func (d *Drainer) Drain() {
+	d.drainOnce.Do(func() {
+		atomic.StoreInt32(&d.draining, 1)
+
+		if d.opts.PropagationDelay > 0 {
+			timer := time.NewTimer(d.opts.PropagationDelay)
+			defer timer.Stop()
+
+			select {
+			case <-timer.C:
+			case <-d.stop:
+			}
+		}
+		d.closeStop()
+	})
+
+	<-d.stop
+}
+
+// Draining - Drain 진행 여부 반환
+func (d *Drainer) Draining() bool {
+	return atomic.LoadInt32(&d.draining) == 1
+}
+
+// Done - Stop 채널 반환
+func (d *Drainer) Done() <-chan struct{} {
+	return d.stop
+}
+
+// Handler - `/readyz`, `/livez` 요청을 처리하는 http.Handler 반환
+func (d *Drainer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(ReadyzPath, d.ReadyzHandler())
+	mux.Handle(LivezPath, d.LivezHandler())
+	return mux
+}
+
+// ReadyzHandler - Drain 진행 중이면 503, 그 외는 200 을 응답하는 http.Handler 반환
+func (d *Drainer) ReadyzHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if d.Draining() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte("draining"))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	})
+}
+
+// LivezHandler - 프로세스가 응답 가능한 동안 항상 200 을 응답하는 http.Handler 반환
+// conditions:
+// - Drain 중에 Liveness 가 실패하면 종료 처리 중인 컨테이너가 재시작되므로 상태 코드에는 반영하지 않고 본문으로만 전달한다.
+func (d *Drainer) LivezHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		if d.Draining() {
+			w.Write([]byte("ok (draining)"))
+			return
+		}
+		w.Write([]byte("ok"))
+	})
+}
+
+// closeStop - Stop 채널을 한번만 닫는다.
+func (d *Drainer) closeStop() {
+	d.stopOnce.Do(func() {
+		atomic.StoreInt32(&d.draining, 1)
+		close(d.stop)
+	})
+}
+
+// ===== [ Private Functions ] =====
+// ===== [ Public Functions ] =====
+
+// NewDrainer - 지정한 옵션 기준의 Drainer 생성
+func NewDrainer(opts DrainOptions) *Drainer {
+	if opts.PropagationDelay == 0 {
+		opts.PropagationDelay = DefaultPropagationDelay
+	}
+
+	return &Drainer{
+		opts: opts,
+		stop: make(chan struct{}),
+	}
+}
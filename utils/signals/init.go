@@ -0,0 +1,89 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package signals
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// ===== [ Constants and Variables ] =====
+const (
+	DefaultReapInterval = time.Second // 시그널 누락에 대비한 주기적 자식 프로세스 회수 간격
+)
+
+var (
+	ErrInitNonFileStdio = errors.New("init mode requires nil or *os.File stdio") // 표준 입출력이 파일이 아닌 경우
+)
+
+// ===== [ Types ] =====
+type (
+	// InitOptions - PID 1 (init) 모드 구성 옵션
+	InitOptions struct {
+		Signals      []os.Signal   // 자식 프로세스 그룹으로 전달할 시그널 (nil 이면 기본 목록)
+		Source       Source        // 시그널 Source (nil 이면 OSSource)
+		ReapInterval time.Duration // 주기적 회수 간격 (0 이면 DefaultReapInterval)
+	}
+)
+
+// ===== [ Implementations ] =====
+// ===== [ Private Functions ] =====
+
+// checkInitStdio - 자식 프로세스의 표준 입출력이 nil 또는 *os.File 인지 검증
+// conditions:
+// - 파일이 아닌 경우는 exec.Cmd 가 복사 고루틴을 사용하므로 cmd.Wait 없이 회수할 수 없다.
+func checkInitStdio(cmd *exec.Cmd) error {
+	if cmd.Stdin != nil {
+		if _, ok := cmd.Stdin.(*os.File); !ok {
+			return ErrInitNonFileStdio
+		}
+	}
+	if cmd.Stdout != nil {
+		if _, ok := cmd.Stdout.(*os.File); !ok {
+			return ErrInitNonFileStdio
+		}
+	}
+	if cmd.Stderr != nil {
+		if _, ok := cmd.Stderr.(*os.File); !ok {
+			return ErrInitNonFileStdio
+		}
+	}
+	return nil
+}
+
+// ===== [ Public Functions ] =====
+
+// RunInit - PID 1 (init) 모드로 자식 프로세스를 실행하고 종료 코드 반환
+// conditions:
+// - POSIX 환경에서는 자식 프로세스를 별도 프로세스 그룹으로 실행하고 수신된 시그널을 그룹 전체로 전달한다.
+// - POSIX 환경에서는 wait4 로 고아 좀비 프로세스들을 회수하며, 자식이 시그널로 종료된 경우는 128+시그널 번호를 반환한다.
+// - 고아 프로세스 회수는 PID 1 이거나 Child Subreaper (Linux) 로 지정된 경우만 동작하며, 그 외에는 지정한 자식 프로세스만 회수한다.
+// - wait4(-1) 은 프로세스의 모든 자식을 회수하므로, RunInit 실행 중에는 프로세스 내에서 다른 자식 프로세스 (exec.Cmd 등) 를 시작하면 안된다. (해당 cmd.Wait 가 ECHILD 로 실패한다)
+// - Windows 환경에서는 시그널 전달/회수 없이 자식 프로세스를 실행하고 종료 코드만 반환한다.
+// - 표준 입출력은 nil (부모 프로세스의 것을 사용) 또는 *os.File 이어야 한다.
+// - 반환된 종료 코드는 os.Exit 에 그대로 전달할 수 있다.
+func RunInit(cmd *exec.Cmd, opts InitOptions) (int, error) {
+	if err := checkInitStdio(cmd); err != nil {
+		return -1, err
+	}
+	if cmd.Stdin == nil {
+		cmd.Stdin = os.Stdin
+	}
+	if cmd.Stdout == nil {
+		cmd.Stdout = os.Stdout
+	}
+	if cmd.Stderr == nil {
+		cmd.Stderr = os.Stderr
+	}
+	if opts.Signals == nil {
+		opts.Signals = forwardSignals
+	}
+	if opts.ReapInterval <= 0 {
+		opts.ReapInterval = DefaultReapInterval
+	}
+
+	return runInit(cmd, opts)
+}
@@ -0,0 +1,31 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package signals
+
+import (
+	"syscall"
+)
+
+// ===== [ Constants and Variables ] =====
+const (
+	prSetChildSubreaper = 36 // prctl PR_SET_CHILD_SUBREAPER
+)
+
+var ()
+
+// ===== [ Types ] =====
+type ()
+
+// ===== [ Implementations ] =====
+// ===== [ Private Functions ] =====
+
+// setChildSubreaper - 현재 프로세스를 Child Subreaper 로 지정해서 하위의 고아 프로세스들이 현재 프로세스로 재지정되도록 설정
+func setChildSubreaper() error {
+	if _, _, errno := syscall.RawSyscall(syscall.SYS_PRCTL, prSetChildSubreaper, 1, 0); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// ===== [ Public Functions ] =====
@@ -0,0 +1,128 @@
+//go:build !windows
+
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package signals
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+	"time"
+
+	"k8s.io/klog"
+)
+
+// ===== [ Constants and Variables ] =====
+const ()
+
+var ()
+
+// ===== [ Types ] =====
+type ()
+
+// ===== [ Implementations ] =====
+// ===== [ Private Functions ] =====
+
+// runInit - 자식 프로세스를 프로세스 그룹으로 실행하고 시그널 전달 및 좀비 프로세스 회수
+// conditions:
+// - PID 1 이 아닌 경우는 Child Subreaper 로 지정해야 고아 프로세스가 재지정되므로, 지정할 수 없으면 지정한 자식 프로세스만 회수한다.
+func runInit(cmd *exec.Cmd, opts InitOptions) (int, error) {
+	reapOrphans := os.Getpid() == 1
+	if !reapOrphans {
+		if err := setChildSubreaper(); err != nil {
+			klog.Warningf("not running as pid 1 and cannot become child subreaper, orphaned processes will not be reaped: %v", err)
+		} else {
+			reapOrphans = true
+		}
+	}
+
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+
+	// 자식 프로세스 시작 전에 등록해야 SIGCHLD 를 놓치지 않는다.
+	src := sourceOrDefault(opts.Source)
+	c := make(chan os.Signal, 32)
+	src.Notify(c, append([]os.Signal{syscall.SIGCHLD}, opts.Signals...)...)
+	defer src.Stop(c)
+
+	if err := cmd.Start(); err != nil {
+		return -1, err
+	}
+	pid := cmd.Process.Pid
+	defer cmd.Process.Release()
+
+	ticker := time.NewTicker(opts.ReapInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case sig := <-c:
+			if sig != syscall.SIGCHLD {
+				forwardSignal(pid, sig)
+				continue
+			}
+		case <-ticker.C:
+		}
+
+		if code, exited := reapChildren(pid, reapOrphans); exited {
+			return code, nil
+		}
+	}
+}
+
+// forwardSignal - 지정한 프로세스 그룹으로 시그널 전달
+func forwardSignal(pgid int, sig os.Signal) {
+	s, ok := sig.(syscall.Signal)
+	if !ok {
+		return
+	}
+
+	if err := syscall.Kill(-pgid, s); err != nil && err != syscall.ESRCH {
+		klog.Errorf("forward signal failed, pgid=%d, signal=%v, err=%v", pgid, sig, err)
+	}
+}
+
+// reapChildren - 종료된 자식 프로세스를 회수하고 지정한 자식 프로세스의 종료 여부와 종료 코드 반환
+// conditions:
+// - reapOrphans 가 true 면 wait4(-1) 로 모든 자식 프로세스를 회수하고, false 면 지정한 자식 프로세스만 회수한다.
+func reapChildren(pid int, reapOrphans bool) (int, bool) {
+	code, exited := -1, false
+
+	target := pid
+	if reapOrphans {
+		target = -1
+	}
+	for {
+		var ws syscall.WaitStatus
+		wpid, err := syscall.Wait4(target, &ws, syscall.WNOHANG, nil)
+		if err == syscall.EINTR {
+			continue
+		}
+		if err != nil || wpid <= 0 {
+			break
+		}
+
+		if wpid != pid {
+			if klog.V(2) {
+				klog.Infof("reaped orphaned process, pid=%d, status=%v", wpid, ws)
+			}
+			continue
+		}
+
+		exited = true
+		switch {
+		case ws.Exited():
+			code = ws.ExitStatus()
+		case ws.Signaled():
+			code = 128 + int(ws.Signal())
+		}
+	}
+
+	return code, exited
+}
+
+// ===== [ Public Functions ] =====
@@ -0,0 +1,28 @@
+//go:build !windows && !linux
+
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package signals
+
+import (
+	"errors"
+)
+
+// ===== [ Constants and Variables ] =====
+const ()
+
+var ()
+
+// ===== [ Types ] =====
+type ()
+
+// ===== [ Implementations ] =====
+// ===== [ Private Functions ] =====
+
+// setChildSubreaper - Child Subreaper 를 지원하지 않는 플랫폼
+func setChildSubreaper() error {
+	return errors.New("child subreaper is not supported on this platform")
+}
+
+// ===== [ Public Functions ] =====
@@ -0,0 +1,36 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package signals
+
+import (
+	"errors"
+	"os/exec"
+)
+
+// ===== [ Constants and Variables ] =====
+const ()
+
+var ()
+
+// ===== [ Types ] =====
+type ()
+
+// ===== [ Implementations ] =====
+// ===== [ Private Functions ] =====
+
+// runInit - 자식 프로세스를 실행하고 종료 코드 반환 (윈도우 환경은 시그널 전달/회수 미지원)
+func runInit(cmd *exec.Cmd, opts InitOptions) (int, error) {
+	err := cmd.Run()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return -1, err
+	}
+	return 0, nil
+}
+
+// ===== [ Public Functions ] =====
@@ -0,0 +1,219 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package signals
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"k8s.io/klog"
+)
+
+// ===== [ Constants and Variables ] =====
+const (
+	DefaultReloadDebounce = 500 * time.Millisecond // 연속으로 수신된 재적재 요청을 병합하는 기본 대기 시간
+)
+
+var ()
+
+// ===== [ Types ] =====
+type (
+	// ReloadFunc - 설정 재적재 시점에 호출되는 함수
+	ReloadFunc func(ctx context.Context) error
+
+	// ReloadError - 재적재 함수 실행 오류
+	ReloadError struct {
+		Name string // 구독 이름
+		Err  error  // 발생한 오류
+	}
+
+	// ReloadOptions - Reloader 구성 옵션
+	ReloadOptions struct {
+		Debounce time.Duration      // 연속 요청 병합 대기 시간 (0 이면 DefaultReloadDebounce, 음수면 병합하지 않음)
+		Source   Source             // 시그널 Source (nil 이면 OSSource)
+		OnError  func(*ReloadError) // 재적재 함수 오류 통지 (nil 이면 로그만 출력)
+	}
+
+	// Reloader - SIGHUP 수신 시 등록된 재적재 함수들을 순차적으로 실행하는 관리자
+	Reloader struct {
+		opts    ReloadOptions
+		mu      sync.Mutex
+		runMu   sync.Mutex
+		subs    []reloadSubscription
+		nextID  int
+		started bool
+		trigger chan struct{}
+	}
+
+	// reloadSubscription - 재적재 함수 구독 정보
+	reloadSubscription struct {
+		id   int
+		name string
+		fn   ReloadFunc
+	}
+)
+
+// ===== [ Implementations ] =====
+
+// Error - 오류 메시지 반환
+func (e *ReloadError) Error() string {
+	return fmt.Sprintf("reload %s failed: %v", e.Name, e.Err)
+}
+
+// Unwrap - 원본 오류 반환
+func (e *ReloadError) Unwrap() error {
+	return e.Err
+}
+
+// Subscribe - 재적재 함수 등록 후 등록 해제 함수 반환
+// conditions:
+// - 재적재 함수는 등록 순서대로 한번에 하나씩 실행된다.
+func (r *Reloader) Subscribe(name string, fn ReloadFunc) (unsubscribe func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	id := r.nextID
+	r.subs = append(r.subs, reloadSubscription{id: id, name: name, fn: fn})
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		for i, sub := range r.subs {
+			if sub.id == id {
+				r.subs = append(r.subs[:i:i], r.subs[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Trigger - 시그널 수신과 동일하게 재적재 요청
+// conditions:
+// - Start 로 실행 중인 경우에만 처리되며, 처리 대기 중인 요청이 있으면 병합된다.
+func (r *Reloader) Trigger() {
+	select {
+	case r.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// Reload - 등록된 재적재 함수들을 즉시 순차 실행하고 발생한 오류들 반환
+// conditions:
+// - 동시에 호출되더라도 재적재는 한번에 하나씩 실행된다.
+// - 함수 오류가 발생해도 나머지 함수들은 계속 실행된다.
+func (r *Reloader) Reload(ctx context.Context) []error {
+	r.runMu.Lock()
+	defer r.runMu.Unlock()
+
+	r.mu.Lock()
+	subs := make([]reloadSubscription, len(r.subs))
+	copy(subs, r.subs)
+	r.mu.Unlock()
+
+	var errs []error
+	for _, sub := range subs {
+		if err := sub.fn(ctx); err != nil {
+			rerr := &ReloadError{Name: sub.name, Err: err}
+			klog.Errorf("reload subscriber failed, name=%s, err=%v", sub.name, err)
+			if r.opts.OnError != nil {
+				r.opts.OnError(rerr)
+			}
+			errs = append(errs, rerr)
+		}
+	}
+
+	return errs
+}
+
+// Start - 재적재 시그널 (SIGHUP) 수신 대기를 시작
+// conditions:
+// - 지정한 Context 가 종료되면 시그널 수신 등록을 해제하고 종료한다.
+// - 재적재 시그널을 지원하지 않는 환경 (Windows) 에서는 Trigger 요청만 처리한다.
+// - 한번만 시작되며, 이후 호출은 무시된다.
+func (r *Reloader) Start(ctx context.Context) {
+	r.mu.Lock()
+	started := r.started
+	r.started = true
+	r.mu.Unlock()
+	if started {
+		klog.Warning("reloader is already started")
+		return
+	}
+
+	src := sourceOrDefault(r.opts.Source)
+	c := make(chan os.Signal, 1)
+	if len(reloadSignals) > 0 {
+		src.Notify(c, reloadSignals...)
+	}
+
+	go func() {
+		defer src.Stop(c)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-c:
+			case <-r.trigger:
+			}
+
+			if !r.debounce(ctx, c) {
+				return
+			}
+			r.Reload(ctx)
+		}
+	}()
+}
+
+// debounce - 대기 시간 동안 추가로 수신된 요청들을 병합
+// conditions:
+// - 요청이 수신될 때마다 대기 시간을 다시 시작하므로, 마지막 요청 이후 대기 시간 동안 요청이 없어야 true 반환
+// - Context 가 종료되면 false 반환
+func (r *Reloader) debounce(ctx context.Context, c <-chan os.Signal) bool {
+	if r.opts.Debounce < 0 {
+		return true
+	}
+
+	timer := time.NewTimer(r.opts.Debounce)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-c:
+		case <-r.trigger:
+		case <-timer.C:
+			return true
+		}
+
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(r.opts.Debounce)
+	}
+}
+
+// ===== [ Private Functions ] =====
+// ===== [ Public Functions ] =====
+
+// NewReloader - 지정한 옵션 기준의 Reloader 생성
+func NewReloader(opts ReloadOptions) *Reloader {
+	if opts.Debounce == 0 {
+		opts.Debounce = DefaultReloadDebounce
+	}
+
+	return &Reloader{
+		opts:    opts,
+		trigger: make(chan struct{}, 1),
+	}
+}
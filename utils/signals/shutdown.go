@@ -0,0 +1,253 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package signals
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/klog"
+)
+
+// ===== [ Constants and Variables ] =====
+const (
+	DefaultShutdownTimeout = 30 * time.Second // 전체 종료 처리의 기본 제한 시간
+)
+
+var (
+	ErrHookTimeout     = errors.New("shutdown hook timed out")          // Hook 개별 제한 시간을 초과한 경우
+	ErrShutdownTimeout = errors.New("shutdown deadline exceeded")       // 전체 제한 시간을 초과한 경우
+	ErrDuplicateHook   = errors.New("shutdown hook already registered") // 동일한 이름의 Hook 이 이미 등록된 경우
+	ErrInvalidHook     = errors.New("shutdown hook requires name and function")
+)
+
+// ===== [ Types ] =====
+type (
+	// HookFunc - 종료 시점에 호출되는 함수
+	// conditions:
+	// - 전달된 Context 가 종료되기 전에 처리를 완료해야 한다.
+	HookFunc func(ctx context.Context) error
+
+	// ShutdownHook - 종료 시점에 실행할 Hook 정보
+	ShutdownHook struct {
+		Name     string        // Hook 식별 이름 (ex. http-server, queue, db)
+		Priority int           // 실행 순서 (값이 작은 Hook 부터 실행, 같은 값이면 등록 순서)
+		Timeout  time.Duration // Hook 개별 제한 시간 (0 이하면 전체 제한 시간만 적용)
+		Fn       HookFunc      // 실행할 함수
+	}
+
+	// HookResult - Hook 실행 결과
+	HookResult struct {
+		Name     string        // Hook 식별 이름
+		Err      error         // 실행 오류 (ErrHookTimeout, ErrShutdownTimeout 포함)
+		Duration time.Duration // 실행 소요 시간
+	}
+
+	// ShutdownReport - 종료 처리 결과
+	ShutdownReport struct {
+		Results  []HookResult  // Hook 실행 순서대로의 결과
+		Duration time.Duration // 전체 소요 시간
+	}
+
+	// ShutdownManager - 우선 순위 기반의 종료 Hook 관리자
+	// conditions:
+	// - Zero 값은 DefaultShutdownTimeout 을 사용한다.
+	ShutdownManager struct {
+		mu      sync.Mutex
+		hooks   []ShutdownHook
+		timeout time.Duration
+		once    sync.Once
+		report  *ShutdownReport
+	}
+)
+
+// ===== [ Implementations ] =====
+
+// TimedOut - Hook 이 개별 또는 전체 제한 시간을 초과했는지 여부
+func (r HookResult) TimedOut() bool {
+	return errors.Is(r.Err, ErrHookTimeout) || errors.Is(r.Err, ErrShutdownTimeout)
+}
+
+// Failed - 오류가 발생한 (제한 시간 초과 포함) Hook 결과들 반환
+func (r *ShutdownReport) Failed() []HookResult {
+	var failed []HookResult
+	for _, result := range r.Results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// TimedOut - 제한 시간을 초과한 Hook 결과들 반환
+func (r *ShutdownReport) TimedOut() []HookResult {
+	var timedOut []HookResult
+	for _, result := range r.Results {
+		if result.TimedOut() {
+			timedOut = append(timedOut, result)
+		}
+	}
+	return timedOut
+}
+
+// Err - 실패한 Hook 들을 하나의 오류로 반환
+// conditions:
+// - 모든 Hook 이 성공한 경우는 nil 반환
+func (r *ShutdownReport) Err() error {
+	failed := r.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+
+	msgs := make([]string, 0, len(failed))
+	for _, result := range failed {
+		msgs = append(msgs, fmt.Sprintf("%s: %v", result.Name, result.Err))
+	}
+	return fmt.Errorf("shutdown hooks failed: %s", strings.Join(msgs, "; "))
+}
+
+// Register - 종료 Hook 등록
+// conditions:
+// - 이름과 함수는 필수이며, 동일한 이름은 중복 등록할 수 없다.
+// - 종료 처리가 시작된 이후에 등록된 Hook 은 실행되지 않는다.
+func (m *ShutdownManager) Register(hook ShutdownHook) error {
+	if hook.Name == "" || hook.Fn == nil {
+		return ErrInvalidHook
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, h := range m.hooks {
+		if h.Name == hook.Name {
+			return fmt.Errorf("%w: %s", ErrDuplicateHook, hook.Name)
+		}
+	}
+	m.hooks = append(m.hooks, hook)
+	return nil
+}
+
+// Run - 지정한 채널이 닫힐 때까지 대기한 후 종료 Hook 들을 실행하고 결과 반환
+// conditions:
+// - SetupSignalHandler 가 반환한 채널을 전달하면 첫번째 SIGTERM/SIGINT 에서 종료 처리가 시작된다.
+func (m *ShutdownManager) Run(stopCh <-chan struct{}) *ShutdownReport {
+	<-stopCh
+	return m.Shutdown(context.Background())
+}
+
+// Shutdown - 등록된 종료 Hook 들을 우선 순위 순서대로 실행하고 결과 반환
+// conditions:
+// - 한번만 실행되며, 이후 호출은 최초 실행 결과를 반환한다.
+// - 전체 제한 시간을 초과하면 남은 Hook 들은 실행하지 않고 ErrShutdownTimeout 으로 기록한다.
+// - 전체 제한 시간이 지정되지 않은 경우 (Zero 값) 는 DefaultShutdownTimeout 을 사용한다.
+func (m *ShutdownManager) Shutdown(ctx context.Context) *ShutdownReport {
+	m.once.Do(func() {
+		m.mu.Lock()
+		hooks := make([]ShutdownHook, len(m.hooks))
+		copy(hooks, m.hooks)
+		m.mu.Unlock()
+
+		sort.SliceStable(hooks, func(i, j int) bool {
+			return hooks[i].Priority < hooks[j].Priority
+		})
+
+		timeout := m.timeout
+		if timeout <= 0 {
+			timeout = DefaultShutdownTimeout
+		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		start := time.Now()
+		report := &ShutdownReport{Results: make([]HookResult, 0, len(hooks))}
+		for _, hook := range hooks {
+			result := runHook(ctx, hook)
+			if result.Err != nil {
+				klog.Errorf("shutdown hook failed, name=%s, err=%v", result.Name, result.Err)
+			} else if klog.V(2) {
+				klog.Infof("shutdown hook completed, name=%s, cost=%v", result.Name, result.Duration)
+			}
+			report.Results = append(report.Results, result)
+		}
+		report.Duration = time.Since(start)
+
+		m.mu.Lock()
+		m.report = report
+		m.mu.Unlock()
+	})
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.report
+}
+
+// ===== [ Private Functions ] =====
+
+// runHook - 지정한 Hook 을 제한 시간 내에 실행
+// conditions:
+// - 제한 시간을 초과하면 Hook 의 종료를 기다리지 않고 다음 단계로 진행한다.
+// - Hook 에서 발생한 Panic 은 오류로 변환한다.
+func runHook(ctx context.Context, hook ShutdownHook) HookResult {
+	result := HookResult{Name: hook.Name}
+
+	// 전체 제한 시간이 이미 초과된 경우는 실행 생략
+	if ctx.Err() != nil {
+		result.Err = ErrShutdownTimeout
+		return result
+	}
+
+	hookCtx, cancel := ctx, context.CancelFunc(func() {})
+	if hook.Timeout > 0 {
+		hookCtx, cancel = context.WithTimeout(ctx, hook.Timeout)
+	}
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("shutdown hook panic: %v", r)
+			}
+		}()
+		done <- hook.Fn(hookCtx)
+	}()
+
+	select {
+	case err := <-done:
+		result.Err = err
+	case <-hookCtx.Done():
+		result.Err = hookCtx.Err()
+	}
+
+	// 제한 시간 초과는 Hook 이 반환한 Context 오류와 관계없이 동일하게 구분
+	if errors.Is(result.Err, context.DeadlineExceeded) && hookCtx.Err() != nil {
+		if ctx.Err() != nil {
+			result.Err = ErrShutdownTimeout
+		} else {
+			result.Err = ErrHookTimeout
+		}
+	}
+	result.Duration = time.Since(start)
+
+	return result
+}
+
+// ===== [ Public Functions ] =====
+
+// NewShutdownManager - 지정한 전체 제한 시간을 사용하는 종료 관리자 생성
+// conditions:
+// - 제한 시간이 0 이하면 DefaultShutdownTimeout 사용
+func NewShutdownManager(timeout time.Duration) *ShutdownManager {
+	if timeout <= 0 {
+		timeout = DefaultShutdownTimeout
+	}
+
+	return &ShutdownManager{timeout: timeout}
+}
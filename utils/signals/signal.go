@@ -8,18 +8,102 @@ package signals
 
 import (
 	"os"
-	"os/signal"
+	"sync"
+	"time"
 )
 
 // ===== [ Constants and Variables ] =====
-const ()
+const (
+	DefaultForceSignalCount = 2 // 강제 종료까지의 기본 시그널 수
+	DefaultForceExitCode    = 1 // 강제 종료 시 기본 종료 코드
+)
 
 var onlyOneSignalHandler = make(chan struct{}) // 단일 시그널 처리기 관리용
 
 // ===== [ Types ] =====
-type ()
+type (
+	// HandlerOptions - 종료 시그널 처리기 구성 옵션
+	HandlerOptions struct {
+		ForceSignalCount int                 // 강제 종료까지의 시그널 수 (0 이면 DefaultForceSignalCount, 음수면 시그널로 강제 종료하지 않음)
+		ExitCode         int                 // 강제 종료 코드 (0 이면 DefaultForceExitCode, UseExitCode 가 true 면 그대로 사용)
+		UseExitCode      bool                // ExitCode 를 그대로 사용할지 여부 (0 으로 강제 종료하는 경우에 지정)
+		HardDeadline     time.Duration       // 첫번째 시그널 이후 강제 종료까지의 제한 시간 (0 이하면 제한 없음)
+		OnForce          func(sig os.Signal) // 강제 종료 직전에 호출 (로그 flush 등), 제한 시간 초과인 경우는 nil 전달
+		Source           Source              // 시그널 Source (nil 이면 OSSource)
+		Exit             func(code int)      // 종료 함수 (nil 이면 os.Exit)
+	}
+
+	// Handler - 종료 시그널 처리기
+	Handler struct {
+		opts HandlerOptions
+		stop chan struct{}
+		mu   sync.Mutex
+		sig  os.Signal
+	}
+)
 
 // ===== [ Implementations ] =====
+
+// Done - 첫번째 종료 시그널 수신 시 닫히는 채널 반환
+func (h *Handler) Done() <-chan struct{} {
+	return h.stop
+}
+
+// Signal - 종료를 유발한 시그널 반환
+// conditions:
+// - 아직 시그널이 수신되지 않은 경우는 nil 반환
+func (h *Handler) Signal() os.Signal {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.sig
+}
+
+// run - 시그널 수신 대기 및 강제 종료 정책 처리
+func (h *Handler) run(src Source, c chan os.Signal) {
+	defer src.Stop(c)
+
+	sig := <-c
+	h.mu.Lock()
+	h.sig = sig
+	h.mu.Unlock()
+	close(h.stop)
+
+	count := 1
+	if h.opts.ForceSignalCount > 0 && count >= h.opts.ForceSignalCount {
+		h.force(sig)
+		return
+	}
+
+	var deadline <-chan time.Time
+	if h.opts.HardDeadline > 0 {
+		timer := time.NewTimer(h.opts.HardDeadline)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	for {
+		select {
+		case sig = <-c:
+			count++
+			if h.opts.ForceSignalCount > 0 && count >= h.opts.ForceSignalCount {
+				h.force(sig)
+				return
+			}
+		case <-deadline:
+			h.force(nil)
+			return
+		}
+	}
+}
+
+// force - OnForce 호출 후 지정한 종료 코드로 강제 종료
+func (h *Handler) force(sig os.Signal) {
+	if h.opts.OnForce != nil {
+		h.opts.OnForce(sig)
+	}
+	h.opts.Exit(h.opts.ExitCode)
+}
+
 // ===== [ Private Functions ] =====
 // ===== [ Public Functions ] =====
 
@@ -30,15 +114,37 @@ type ()
 func SetupSignalHandler() (stopCh <-chan struct{}) {
 	close(onlyOneSignalHandler) // 두번 호출되면 Panic 발생
 
-	stop := make(chan struct{})
-	c := make(chan os.Signal, 2)
-	signal.Notify(c, shutdownSignals...)
-	go func() {
-		<-c
-		close(stop)
-		<-c
-		os.Exit(1) // second signal. Exit directly.
-	}()
-
-	return stop
+	return SetupSignalHandlerWithOptions(HandlerOptions{}).Done()
+}
+
+// SetupSignalHandlerWithOptions - 지정한 강제 종료 정책으로 SIGTERM and SIGINT 등록
+// conditions:
+// - SetupSignalHandler 와 달리 여러 번 호출할 수 있다.
+// - 지정한 수의 시그널이 수신되거나 첫번째 시그널 이후 제한 시간이 지나면 OnForce 호출 후 강제 종료한다.
+func SetupSignalHandlerWithOptions(opts HandlerOptions) *Handler {
+	if opts.ForceSignalCount == 0 {
+		opts.ForceSignalCount = DefaultForceSignalCount
+	}
+	if opts.ExitCode == 0 && !opts.UseExitCode {
+		opts.ExitCode = DefaultForceExitCode
+	}
+	if opts.Exit == nil {
+		opts.Exit = os.Exit
+	}
+
+	size := opts.ForceSignalCount
+	if size < 2 {
+		size = 2
+	}
+
+	h := &Handler{
+		opts: opts,
+		stop: make(chan struct{}),
+	}
+	src := sourceOrDefault(opts.Source)
+	c := make(chan os.Signal, size)
+	src.Notify(c, shutdownSignals...)
+	go h.run(src, c)
+
+	return h
 }
@@ -13,7 +13,11 @@ import (
 // ===== [ Constants and Variables ] =====
 const ()
 
-var shutdownSignals = []os.Signal{os.Interrupt, syscall.SIGTERM} // POSIX 환경인 경우 종료 시그널
+var shutdownSignals = []os.Signal{os.Interrupt, syscall.SIGTERM}                                                                                       // POSIX 환경인 경우 종료 시그널
+var reloadSignals = []os.Signal{syscall.SIGHUP}                                                                                                        // POSIX 환경인 경우 설정 재적재 시그널
+var dumpSignals = []os.Signal{syscall.SIGUSR1}                                                                                                         // POSIX 환경인 경우 진단 정보 출력 시그널
+var verbositySignals = []os.Signal{syscall.SIGUSR2}                                                                                                    // POSIX 환경인 경우 로그 레벨 전환 시그널
+var forwardSignals = []os.Signal{syscall.SIGHUP, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM, syscall.SIGUSR1, syscall.SIGUSR2, syscall.SIGWINCH} // POSIX 환경인 경우 자식 프로세스로 전달할 시그널
 
 // ===== [ Types ] =====
 type ()
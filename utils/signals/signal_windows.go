@@ -9,6 +9,10 @@ import "os"
 const ()
 
 var shutdownSignals = []os.Signal{os.Interrupt} // 윈도우 환경인 경우 종료 시그널
+var reloadSignals []os.Signal                   // 윈도우 환경은 설정 재적재 시그널 미지원
+var dumpSignals []os.Signal                     // 윈도우 환경은 진단 정보 출력 시그널 미지원
+var verbositySignals []os.Signal                // 윈도우 환경은 로그 레벨 전환 시그널 미지원
+var forwardSignals []os.Signal                  // 윈도우 환경은 자식 프로세스 시그널 전달 미지원
 
 // ===== [ Types ] =====
 type ()
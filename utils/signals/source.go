@@ -0,0 +1,122 @@
+/*
+Copyright 2021 MSFL Authors. All right reserved.
+*/
+package signals
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+)
+
+// ===== [ Constants and Variables ] =====
+const ()
+
+var OSSource Source = osSource{} // 실제 프로세스 시그널을 사용하는 Source
+
+// ===== [ Types ] =====
+type (
+	// Source - 시그널 수신 채널 등록/해제 기능 제공 (os/signal 과 동일한 방식)
+	Source interface {
+		Notify(c chan<- os.Signal, sig ...os.Signal)
+		Stop(c chan<- os.Signal)
+	}
+
+	// osSource - os/signal 패키지 기반의 Source
+	osSource struct{}
+
+	// FakeSource - 테스트에서 프로세스와 무관하게 시그널을 전달할 수 있는 Source
+	FakeSource struct {
+		mu    sync.Mutex
+		chans map[chan<- os.Signal][]os.Signal
+	}
+)
+
+// ===== [ Implementations ] =====
+
+// Notify - signal.Notify 호출
+func (osSource) Notify(c chan<- os.Signal, sig ...os.Signal) {
+	signal.Notify(c, sig...)
+}
+
+// Stop - signal.Stop 호출
+func (osSource) Stop(c chan<- os.Signal) {
+	signal.Stop(c)
+}
+
+// Notify - 지정한 채널로 지정한 시그널들을 전달하도록 등록
+// conditions:
+// - 시그널을 지정하지 않으면 모든 시그널을 전달한다.
+// - 동일한 채널을 다시 등록하면 시그널 목록이 추가된다.
+func (f *FakeSource) Notify(c chan<- os.Signal, sig ...os.Signal) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.chans == nil {
+		f.chans = make(map[chan<- os.Signal][]os.Signal)
+	}
+	if len(sig) == 0 {
+		f.chans[c] = nil
+		return
+	}
+	if sigs, ok := f.chans[c]; ok && sigs == nil {
+		return // 이미 모든 시그널 수신 중
+	}
+	f.chans[c] = append(f.chans[c], sig...)
+}
+
+// Stop - 지정한 채널로의 시그널 전달 해제
+func (f *FakeSource) Stop(c chan<- os.Signal) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.chans, c)
+}
+
+// Send - 지정한 시그널을 등록된 채널들로 전달
+// conditions:
+// - os/signal 과 동일하게 채널이 가득 찬 경우는 전달하지 않는다 (non-blocking).
+func (f *FakeSource) Send(sig os.Signal) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for c, sigs := range f.chans {
+		if !containsSignal(sigs, sig) {
+			continue
+		}
+		select {
+		case c <- sig:
+		default:
+		}
+	}
+}
+
+// ===== [ Private Functions ] =====
+
+// containsSignal - 지정한 시그널 목록에 시그널이 존재하는지 여부 (nil 목록은 모든 시그널)
+func containsSignal(sigs []os.Signal, sig os.Signal) bool {
+	if sigs == nil {
+		return true
+	}
+	for _, s := range sigs {
+		if s == sig {
+			return true
+		}
+	}
+	return false
+}
+
+// sourceOrDefault - 지정한 Source 가 없으면 OSSource 반환
+func sourceOrDefault(src Source) Source {
+	if src == nil {
+		return OSSource
+	}
+	return src
+}
+
+// ===== [ Public Functions ] =====
+
+// NewFakeSource - 테스트용 FakeSource 생성
+func NewFakeSource() *FakeSource {
+	return &FakeSource{chans: make(map[chan<- os.Signal][]os.Signal)}
+}